@@ -0,0 +1,75 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/jessevdk/go-flags"
+)
+
+// envPrefix is prepended to an option's long name to form the environment variable that overrides it
+const envPrefix = "SOURCESEER_"
+
+// newParser binds the options struct pointed to by opts to a command line parser; every option can also be set by an
+// environment variable
+func newParser(name string, opts interface{}) (*flags.Parser, error) {
+	p := flags.NewNamedParser("sourceseer "+name, flags.HelpFlag|flags.PassDoubleDash)
+
+	if _, err := p.AddGroup("Options", "", opts); err != nil {
+		return nil, err
+	}
+
+	eachOption(p.Group, func(o *flags.Option) {
+		o.EnvDefaultKey = envName(o.LongName)
+	})
+
+	return p, nil
+}
+
+// parseOptions reads the options from the command line then from the ini file named by config, if any; the command
+// line beats the config file, which beats the environment. Returns the positional arguments.
+func parseOptions(p *flags.Parser, argv []string, config *string) ([]string, error) {
+	args, err := p.ParseArgs(argv)
+	if err != nil || len(*config) == 0 {
+		return args, err
+	}
+
+	ini := flags.NewIniParser(p)
+	ini.ParseAsDefaults = true
+
+	if err := ini.ParseFile(*config); err != nil {
+		return nil, err
+	}
+
+	return args, nil
+}
+
+// showHiddenOptions includes every option in the parser's help
+func showHiddenOptions(p *flags.Parser) {
+	eachOption(p.Group, func(o *flags.Option) {
+		o.Hidden = false
+	})
+}
+
+func eachOption(g *flags.Group, f func(*flags.Option)) {
+	for _, o := range g.Options() {
+		f(o)
+	}
+
+	for _, sub := range g.Groups() {
+		eachOption(sub, f)
+	}
+}
+
+// envName returns the environment variable that overrides the option with the long name
+func envName(long string) string {
+	return envPrefix + strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9'):
+			return r
+		default:
+			return '_'
+		}
+	}, long)
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds/csgo"
+)
+
+func Test_envName(t *testing.T) {
+	validCases := map[string]string{
+		"map":           "SOURCESEER_MAP",
+		"mp_teamname_1": "SOURCESEER_MP_TEAMNAME_1",
+		"log-level":     "SOURCESEER_LOG_LEVEL",
+	}
+
+	for long, expected := range validCases {
+		if actual := envName(long); actual != expected {
+			t.Errorf("Expected option %q to be set by %v not %v.", long, expected, actual)
+		}
+	}
+}
+
+func Test_parseOptions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sourceseer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	config := filepath.Join(dir, "csgo.ini")
+	contents := "; tournament defaults\nmap = de_nuke\nmp_teamname_1 = \"Team One\"\ntickrate = 64\nuserrcon = true\nrcon_password = fromfile\n"
+	if err := ioutil.WriteFile(config, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	os.Setenv("SOURCESEER_CONFIG", config)
+	os.Setenv("SOURCESEER_MP_TEAMNAME_1", "Env Team")
+	os.Setenv("SOURCESEER_TV_NAME", "Env TV")
+	os.Setenv("SOURCESEER_MAP", "de_dust2")
+	defer os.Unsetenv("SOURCESEER_CONFIG")
+	defer os.Unsetenv("SOURCESEER_MP_TEAMNAME_1")
+	defer os.Unsetenv("SOURCESEER_TV_NAME")
+	defer os.Unsetenv("SOURCESEER_MAP")
+
+	opts, common := newRunCSGOOptions()
+	sut, err := newParser("run csgo", opts)
+	if err != nil {
+		t.Fatalf("Options should have bound: %v", err)
+	}
+
+	args, err := parseOptions(sut, []string{"--map", "de_mirage", "--log-level=debug", "--", "-console"}, &common.Config)
+	if err != nil {
+		t.Fatalf("Options should have parsed: %v", err)
+	}
+
+	actual := opts.(*runCSGOOptions)
+	expected := csgo.Args{
+		GameMode:         1,
+		LANMode:          1,
+		Map:              "de_mirage", // command line beats the config file and the environment
+		Password:         "0",
+		RConPassword:     "fromfile",
+		TeamName1:        "Team One", // config file beats the environment
+		TickRate:         64,
+		TVName:           "Env TV",
+		UseRemoteConsole: true,
+	}
+
+	if actual.Args != expected {
+		t.Errorf("Expected args %+v not %+v.", expected, actual.Args)
+	}
+
+	if common.LogLevel != "debug" || common.Config != config || actual.Executable != "./srcds_run" {
+		t.Errorf("Unexpected common options %+v or executable %q.", *common, actual.Executable)
+	}
+
+	if !reflect.DeepEqual(args, []string{"-console"}) {
+		t.Errorf("Expected positional arguments [-console] not %q.", args)
+	}
+
+	invalidCases := [][]string{
+		{"--tickrate", "100"},
+		{"--tickrate", "fast"},
+		{"--log-level", "verbose"},
+		{"--no-such-option"},
+		{"--config", filepath.Join(dir, "missing.ini")},
+	}
+	for _, test := range invalidCases {
+		opts, common := newRunCSGOOptions()
+		sut, _ := newParser("run csgo", opts)
+
+		if _, err := parseOptions(sut, test, &common.Config); err == nil {
+			t.Errorf("Arguments %q should NOT have parsed.", test)
+		}
+	}
+}
+
+func Test_findCommand(t *testing.T) {
+	validCases := map[string][]string{
+		"run csgo": {"run", "csgo", "--map", "de_nuke"},
+		"observe":  {"observe", "--game", "tf2"},
+		"replay":   {"replay", "a.log"},
+	}
+	for expected, argv := range validCases {
+		cmd, rest, found := findCommand(argv)
+		if !found || cmd.name != expected {
+			t.Errorf("Arguments %q should have found command %q.", argv, expected)
+			continue
+		}
+
+		if words := len(strings.Fields(expected)); !reflect.DeepEqual(rest, argv[words:]) {
+			t.Errorf("Command %q should have consumed its name from %q.", expected, argv)
+		}
+	}
+
+	invalidCases := [][]string{{"run"}, {"run", "tf2"}, {"status"}}
+	for _, argv := range invalidCases {
+		if _, _, found := findCommand(argv); found {
+			t.Errorf("Arguments %q should NOT have found a command.", argv)
+		}
+	}
+}
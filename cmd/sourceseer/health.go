@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+	"github.com/rs/zerolog/log"
+)
+
+// healthOptions determine how a running server's health is tracked and exposed
+type healthOptions struct {
+	HealthAddr     string        `long:"health-addr" description:"Address to serve the health endpoint (/healthz) on; e.g. :8080"`
+	HealthFile     string        `long:"health-file" description:"File to write the health to at every probe interval"`
+	HealthInterval time.Duration `long:"health-interval" description:"How often the console is probed and health is checked" default:"30s"`
+	HealthTimeout  time.Duration `long:"health-timeout" description:"Longest the console may take to answer a probe" default:"10s"`
+	HealthSilence  time.Duration `long:"health-silence" description:"Longest SRCDS may go without output; 0 to ignore silence" default:"2m"`
+}
+
+// monitorHealth starts tracking the server's health, exposing it as configured; the returned function records the
+// final health once the server has stopped, as sourceseer may exit before the monitor notices
+func monitorHealth(o healthOptions, s *srcds.Server) (stopped func()) {
+	thresholds := srcds.HealthThresholds{Silence: o.HealthSilence, ProbeInterval: o.HealthInterval, ProbeTimeout: o.HealthTimeout}
+
+	s.MonitorHealth(thresholds, func(h srcds.Health) {
+		if len(o.HealthFile) > 0 {
+			if err := srcds.WriteHealthFile(o.HealthFile, h); err != nil {
+				log.Warn().Err(err).Msg("Couldn't update the health file")
+			}
+		}
+	})
+
+	if len(o.HealthAddr) > 0 {
+		mux := http.NewServeMux()
+		mux.Handle("/healthz", s.HealthHandler())
+
+		go func() {
+			log.Info().Str("addr", o.HealthAddr).Msg("Serving the health endpoint")
+			if err := http.ListenAndServe(o.HealthAddr, mux); err != nil {
+				log.Error().Err(err).Msg("Health endpoint stopped")
+			}
+		}()
+	}
+
+	return func() {
+		if len(o.HealthFile) > 0 {
+			if err := srcds.WriteHealthFile(o.HealthFile, s.Health()); err != nil {
+				log.Warn().Err(err).Msg("Couldn't update the health file")
+			}
+		}
+	}
+}
+
+// checkHealthOptions are the options of "health"
+type checkHealthOptions struct {
+	commonOptions
+	File   string        `long:"file" description:"Health file written by sourceseer run --health-file"`
+	URL    string        `long:"url" description:"Health endpoint served by sourceseer run --health-addr; e.g. http://localhost:8080/healthz"`
+	MaxAge time.Duration `long:"max-age" description:"Oldest a health file may be before it's considered stale" default:"2m"`
+}
+
+func newCheckHealthOptions() (interface{}, *commonOptions) {
+	o := &checkHealthOptions{}
+	return o, &o.commonOptions
+}
+
+// checkHealth succeeds if the health file or endpoint reports a healthy server; for use by Docker's HEALTHCHECK
+func checkHealth(opts interface{}, args []string, _ io.Reader, stdout io.Writer) error {
+	o := opts.(*checkHealthOptions)
+
+	if len(args) > 0 || (len(o.File) == 0) == (len(o.URL) == 0) {
+		return usageError{msg: "health requires either --file or --url"}
+	}
+
+	var h srcds.Health
+	var err error
+
+	if len(o.File) > 0 {
+		h, err = srcds.ReadHealthFile(o.File)
+		if err == nil && o.MaxAge > 0 && time.Since(h.CheckedAt) > o.MaxAge {
+			err = fmt.Errorf("Health file is stale; last checked %v", h.CheckedAt.Format(time.RFC3339))
+		}
+	} else {
+		h, err = fetchHealth(o.URL)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(stdout, healthSummary(h))
+
+	if !h.Healthy {
+		return errors.New("SRCDS is unhealthy")
+	}
+
+	return nil
+}
+
+func fetchHealth(url string) (srcds.Health, error) {
+	client := http.Client{Timeout: 5 * time.Second}
+
+	resp, err := client.Get(url)
+	if err != nil {
+		return srcds.Health{}, fmt.Errorf("Couldn't reach health endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var h srcds.Health
+	if err := json.NewDecoder(resp.Body).Decode(&h); err != nil {
+		return srcds.Health{}, fmt.Errorf("Couldn't parse health endpoint response (%v): %w", resp.Status, err)
+	}
+
+	return h, nil
+}
+
+func healthSummary(h srcds.Health) string {
+	if h.Healthy {
+		return fmt.Sprintf("healthy; map %v, last output %v", h.Map, h.LastOutput.Format(time.RFC3339))
+	}
+
+	return "unhealthy; " + strings.Join(h.Problems, "; ")
+}
@@ -0,0 +1,174 @@
+// Command sourceseer wraps and observes Source Dedicated Server instances. It is intended to be the entrypoint of
+// game server containers; every option can also be set by a SOURCESEER_ environment variable or a --config file.
+//
+//	sourceseer run csgo [options] [-- extra srcds arguments]
+//	sourceseer observe --game csgo < console.log
+//	sourceseer replay --game tf2 match1.log match2.log
+//	sourceseer health --file /tmp/health.json
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	exitOK           = 0
+	exitFailure      = 1
+	exitInvalidUsage = 87
+)
+
+// commonOptions are accepted by every subcommand
+type commonOptions struct {
+	Config    string `long:"config" description:"Read options from an ini file of name = value lines"`
+	LogLevel  string `long:"log-level" description:"Minimum level of log messages" choice:"debug" choice:"info" choice:"warn" choice:"error" default:"info"`
+	LogFormat string `long:"log-format" description:"Format of log messages written to standard error" choice:"console" choice:"json" default:"console"`
+	HelpAll   bool   `long:"help-all" description:"Show every option, including hidden ones" hidden:"true"`
+}
+
+// command is a sourceseer subcommand
+type command struct {
+	name    string
+	summary string
+	options func() (interface{}, *commonOptions)
+	run     func(opts interface{}, args []string, stdin io.Reader, stdout io.Writer) error
+}
+
+// commands returns every subcommand
+func commands() []command {
+	return []command{
+		{name: "run csgo", summary: "Run and observe a CSGO server", options: newRunCSGOOptions, run: runCSGO},
+		{name: "observe", summary: "Observe a log stream read from standard in", options: newObserveOptions, run: observe},
+		{name: "replay", summary: "Observe log files and summarize their matches", options: newReplayOptions, run: replay},
+		{name: "health", summary: "Check the health reported by a running sourceseer", options: newCheckHealthOptions, run: checkHealth},
+	}
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the subcommand named by the arguments, returning the process's exit code
+func run(argv []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(argv) == 0 || argv[0] == "help" || argv[0] == "-h" || argv[0] == "--help" {
+		usage(stderr)
+		return exitInvalidUsage
+	}
+
+	cmd, rest, found := findCommand(argv)
+	if !found {
+		fmt.Fprintf(stderr, "Unknown command %q\n\n", strings.Join(argv, " "))
+		usage(stderr)
+		return exitInvalidUsage
+	}
+
+	opts, common := cmd.options()
+	parser, err := newParser(cmd.name, opts)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitFailure
+	}
+
+	args, err := parseOptions(parser, rest, &common.Config)
+	if flagsErr, ok := err.(*flags.Error); (ok && flagsErr.Type == flags.ErrHelp) || (err == nil && common.HelpAll) {
+		if common.HelpAll {
+			showHiddenOptions(parser)
+		}
+
+		commandUsage(stderr, cmd, parser)
+		return exitInvalidUsage
+	}
+
+	if err != nil {
+		fmt.Fprintf(stderr, "%v\n\n", err)
+		commandUsage(stderr, cmd, parser)
+		return exitInvalidUsage
+	}
+
+	setupLogging(common, stderr)
+
+	if err := cmd.run(opts, args, stdin, stdout); err != nil {
+		log.Error().Err(err).Msgf("sourceseer %v failed", cmd.name)
+
+		var usageErr usageError
+		if errors.As(err, &usageErr) {
+			return exitInvalidUsage
+		}
+
+		return exitFailure
+	}
+
+	return exitOK
+}
+
+// findCommand returns the longest command whose words start the arguments, along with the arguments that follow
+func findCommand(argv []string) (command, []string, bool) {
+	var r command
+	words := 0
+
+	for _, cmd := range commands() {
+		fields := strings.Fields(cmd.name)
+		if len(fields) <= words || len(fields) > len(argv) {
+			continue
+		}
+
+		matched := true
+		for i, f := range fields {
+			matched = matched && argv[i] == f
+		}
+
+		if matched {
+			r, words = cmd, len(fields)
+		}
+	}
+
+	return r, argv[words:], words > 0
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: sourceseer <command> [options]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+
+	for _, cmd := range commands() {
+		fmt.Fprintf(w, "  %-12s %v\n", cmd.name, cmd.summary)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'sourceseer <command> --help' for the options of a command.")
+}
+
+func commandUsage(w io.Writer, cmd command, p *flags.Parser) {
+	fmt.Fprintf(w, "%v\n\n", cmd.summary)
+	p.WriteHelp(w)
+}
+
+func setupLogging(o *commonOptions, w io.Writer) {
+	level, err := zerolog.ParseLevel(o.LogLevel)
+	if err != nil {
+		level = zerolog.InfoLevel
+	}
+	zerolog.SetGlobalLevel(level)
+
+	if o.LogFormat == "json" {
+		log.Logger = zerolog.New(w).With().Timestamp().Logger()
+	} else {
+		log.Logger = log.Output(zerolog.ConsoleWriter{Out: w})
+	}
+}
+
+// usageError is returned by a subcommand whose arguments were invalid
+type usageError struct {
+	msg string
+}
+
+func (e usageError) Error() string {
+	return e.msg
+}
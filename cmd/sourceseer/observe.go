@@ -0,0 +1,175 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+	"github.com/lacledeslan/sourceseer/pkg/srcds/cs2"
+	"github.com/lacledeslan/sourceseer/pkg/srcds/csgo"
+	"github.com/lacledeslan/sourceseer/pkg/srcds/l4d2"
+	"github.com/lacledeslan/sourceseer/pkg/srcds/tf2"
+	"github.com/rs/zerolog/log"
+)
+
+// observeOptions are the options of "observe"; the rules are used until the log stream reports its own
+type observeOptions struct {
+	commonOptions
+	Game         string `long:"game" description:"Game that produced the log stream" choice:"csgo" choice:"cs2" choice:"tf2" choice:"l4d2" default:"csgo"`
+	Halftime     int    `long:"mp_halftime" description:"Whether teams switch sides at halftime (csgo and cs2)" choice:"0" choice:"1" default:"1"`
+	MaxRounds    int    `long:"mp_maxrounds" description:"Rounds in regulation; 0 for the game's default (csgo and cs2)" default:"0"`
+	OTMaxRounds  int    `long:"mp_overtime_maxrounds" description:"Rounds in each overtime; 0 for the game's default (csgo and cs2)" default:"0"`
+	Tournament   int    `long:"mp_tournament" description:"Whether the server runs in tournament mode (tf2)" choice:"0" choice:"1" default:"0"`
+	NoEvents     bool   `long:"no-events" description:"Don't write game events to standard out"`
+	MatchSummary bool   `long:"summary" description:"Write the observed matches to standard out as JSON once the stream ends"`
+}
+
+func newObserveOptions() (interface{}, *commonOptions) {
+	o := &observeOptions{}
+	return o, &o.commonOptions
+}
+
+func newReplayOptions() (interface{}, *commonOptions) {
+	o := &observeOptions{MatchSummary: true}
+	return o, &o.commonOptions
+}
+
+// observe reads a log stream from standard in, writing its game events as they occur
+func observe(opts interface{}, args []string, stdin io.Reader, stdout io.Writer) error {
+	if len(args) > 0 {
+		return usageError{msg: fmt.Sprintf("observe reads standard in; unexpected arguments %q", args)}
+	}
+
+	return observeStream(opts.(*observeOptions), stdin, stdout)
+}
+
+// replay reads log files one after the other as a single log stream
+func replay(opts interface{}, args []string, _ io.Reader, stdout io.Writer) error {
+	if len(args) == 0 {
+		return usageError{msg: "replay requires at least one log file"}
+	}
+
+	var readers []io.Reader
+	for _, path := range args {
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("Couldn't open log file: %w", err)
+		}
+		defer f.Close()
+
+		// Files don't always end with a line break; one keeps their last line from running into the next file's first
+		readers = append(readers, f, lineBreakReader{})
+	}
+
+	return observeStream(opts.(*observeOptions), io.MultiReader(readers...), stdout)
+}
+
+func observeStream(o *observeOptions, r io.Reader, stdout io.Writer) error {
+	w := newEventWriter(stdout, o.Game)
+	if o.NoEvents {
+		w = nil
+	}
+
+	g := newGameObserver(o, w)
+
+	g.read(r)
+	g.wait()
+	log.Debug().Str("game", o.Game).Msg("Log stream ended")
+
+	if o.MatchSummary {
+		enc := json.NewEncoder(stdout)
+		if err := enc.Encode(matchSummary{Game: o.Game, Matches: g.matches()}); err != nil {
+			return fmt.Errorf("Couldn't write match summary: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// gameObserver hides the differences between each game's observer
+type gameObserver struct {
+	read    func(io.Reader)
+	wait    func()
+	matches func() interface{}
+}
+
+// newGameObserver creates an observer for the game; its events are written by w unless it's nil
+func newGameObserver(o *observeOptions, w *eventWriter) gameObserver {
+	switch o.Game {
+	case "cs2":
+		obs := cs2.NewObserver(o.Halftime, o.MaxRounds, o.OTMaxRounds)
+		if w != nil {
+			obs.OnEvent(w.write)
+		}
+		return gameObserver{read: obs.Read, wait: obs.Wait, matches: func() interface{} { return obs.Matches() }}
+	case "tf2":
+		obs := tf2.NewObserver(o.Tournament)
+		if w != nil {
+			obs.OnEvent(w.write)
+		}
+		return gameObserver{read: obs.Read, wait: obs.Wait, matches: func() interface{} { return obs.Matches() }}
+	case "l4d2":
+		obs := l4d2.NewObserver()
+		if w != nil {
+			obs.OnEvent(w.write)
+		}
+		return gameObserver{read: obs.Read, wait: obs.Wait, matches: func() interface{} { return obs.Matches() }}
+	default:
+		obs := csgo.NewObserver(o.Halftime, o.MaxRounds, o.OTMaxRounds)
+		if w != nil {
+			obs.OnEvent(w.write)
+		}
+		return gameObserver{read: obs.Read, wait: obs.Wait, matches: func() interface{} { return obs.Matches() }}
+	}
+}
+
+// matchSummary is written by replay (or observe --summary) once the log stream ends
+type matchSummary struct {
+	Game    string      `json:"game"`
+	Matches interface{} `json:"matches"`
+}
+
+// eventWriter writes game events as JSON lines
+type eventWriter struct {
+	enc  *json.Encoder
+	game string
+	mux  sync.Mutex
+}
+
+// eventLine is a single game event as written by eventWriter
+type eventLine struct {
+	Game  string      `json:"game"`
+	Type  string      `json:"type"`
+	Time  time.Time   `json:"time"`
+	Event interface{} `json:"event"`
+}
+
+func newEventWriter(w io.Writer, game string) *eventWriter {
+	return &eventWriter{enc: json.NewEncoder(w), game: game}
+}
+
+func (w *eventWriter) write(e srcds.Event) {
+	t := reflect.TypeOf(e)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	if err := w.enc.Encode(eventLine{Game: w.game, Type: t.Name(), Time: e.Time(), Event: e}); err != nil {
+		log.Warn().Err(err).Str("event", t.Name()).Msg("Couldn't write game event")
+	}
+}
+
+// lineBreakReader reads a single line break
+type lineBreakReader struct{}
+
+func (lineBreakReader) Read(p []byte) (int, error) {
+	return copy(p, "\n"), io.EOF
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+	"github.com/lacledeslan/sourceseer/pkg/srcds/csgo"
+	"github.com/rs/zerolog/log"
+)
+
+// runCSGOOptions are the options of "run csgo"; the server's own options come from csgo.Args
+type runCSGOOptions struct {
+	commonOptions
+	csgo.Args
+	healthOptions
+	Executable string `long:"srcds" description:"Path to the script or executable that launches SRCDS" default:"./srcds_run"`
+	Events     bool   `long:"events" description:"Write game events to standard out as JSON lines"`
+	LogCopy    string `long:"log-copy" description:"Also write the log stream to a file for replay; entries are dropped rather than stall the server"`
+}
+
+func newRunCSGOOptions() (interface{}, *commonOptions) {
+	o := &runCSGOOptions{}
+	return o, &o.commonOptions
+}
+
+// runCSGO launches a CSGO server and observes it until it exits; positional arguments are passed to SRCDS as-is
+func runCSGO(opts interface{}, args []string, _ io.Reader, stdout io.Writer) error {
+	o := opts.(*runCSGOOptions)
+
+	if err := o.Args.Validate(); err != nil {
+		return usageError{msg: err.Error()}
+	}
+
+	if _, err := srcds.SplitLaunchArgs(args); err != nil {
+		return usageError{msg: fmt.Sprintf("Invalid extra SRCDS arguments: %v", err)}
+	}
+
+	server := csgo.NewServer()
+
+	if o.Events {
+		w := newEventWriter(stdout, "csgo")
+		server.OnEvent(w.write)
+	}
+
+	logCopied := func() {}
+	if len(o.LogCopy) > 0 {
+		var err error
+		if logCopied, err = copyLogStream(o.LogCopy, server.Subscribe("log-copy", 1024, srcds.DropOldest)); err != nil {
+			return err
+		}
+	}
+
+	argv := append(o.Args.AsSlice(), args...)
+	log.Info().Str("srcds", o.Executable).Strs("args", argv).Msg("Starting CSGO server")
+
+	if err := server.SetExec(o.Executable, argv...); err != nil {
+		return err
+	}
+
+	if err := server.Read(); err != nil {
+		return err
+	}
+
+	healthStopped := monitorHealth(o.healthOptions, server.Server)
+
+	server.Wait()
+	healthStopped()
+	logCopied()
+	log.Info().Msg("CSGO server stopped")
+
+	return nil
+}
+
+// copyLogStream writes the subscription's log entries to a file as SRCDS logs them; the returned function waits for the
+// log stream to be written once the server has stopped
+func copyLogStream(path string, sub *srcds.Subscription) (copied func(), err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		sub.Unsubscribe()
+		return nil, fmt.Errorf("Couldn't create log copy: %w", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer f.Close()
+
+		w := bufio.NewWriter(f)
+		for le := range sub.C() {
+			fmt.Fprintf(w, "L %s: %s\n", le.Timestamp.Format("01/02/2006 - 15:04:05"), le.Message)
+
+			// Flush once caught up so the copy trails the server as little as possible
+			if len(sub.C()) == 0 {
+				if err := w.Flush(); err != nil {
+					log.Warn().Err(err).Str("path", path).Msg("Couldn't write log copy")
+				}
+			}
+		}
+
+		if err := w.Flush(); err != nil {
+			log.Warn().Err(err).Str("path", path).Msg("Couldn't write log copy")
+		}
+	}()
+
+	return func() {
+		<-done
+		if n := sub.Dropped(); n > 0 {
+			log.Warn().Uint64("dropped", n).Str("path", path).Msg("Log copy is missing log entries as writing fell behind")
+		}
+	}, nil
+}
@@ -3,6 +3,7 @@ module github.com/lacledeslan/sourceseer
 go 1.13
 
 require (
+	github.com/jessevdk/go-flags v1.4.0
 	github.com/pkg/errors v0.8.1
 	github.com/rs/zerolog v1.17.2
 )
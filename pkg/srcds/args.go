@@ -1,5 +1,10 @@
 package srcds
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Args are the command line options for the Source Dedicated Server executable
 type Args struct {
 	Insecure  bool `long:"insecure" description:"Will start the server without Valve Anti-Cheat." hidden:"true"`
@@ -25,3 +30,104 @@ func (o Args) AsSlice() []string {
 
 	return r
 }
+
+// ParseArgs reads the SRCDS options from a command line (excluding the executable), returning the arguments that
+// aren't SRCDS's own for a game to parse
+func ParseArgs(argv []string) (Args, []LaunchArg, error) {
+	launchArgs, err := SplitLaunchArgs(argv)
+	if err != nil {
+		return Args{}, nil, err
+	}
+
+	r := Args{Bots: true}
+	var rest []LaunchArg
+
+	for _, a := range launchArgs {
+		switch {
+		case a.Is('-', "insecure") && !a.HasValue:
+			r.Insecure = true
+		case a.Is('-', "nobots") && !a.HasValue:
+			r.Bots = false
+		case a.Is('-', "norestart") && !a.HasValue:
+			r.NoRestart = true
+		default:
+			rest = append(rest, a)
+		}
+	}
+
+	return r, rest, nil
+}
+
+// LaunchArg is a single option (-name) or console command (+name) from a SRCDS command line, along with its value
+type LaunchArg struct {
+	Prefix   byte // '-' for options or '+' for console commands
+	Name     string
+	Value    string
+	HasValue bool
+}
+
+// Is determines if the argument has the specified prefix and name
+func (a LaunchArg) Is(prefix byte, name string) bool {
+	return a.Prefix == prefix && a.Name == name
+}
+
+// Tokens returns the argument as command line tokens
+func (a LaunchArg) Tokens() []string {
+	if !a.HasValue {
+		return []string{string(a.Prefix) + a.Name}
+	}
+
+	return []string{string(a.Prefix) + a.Name, a.Value}
+}
+
+// SplitLaunchArgs groups command line tokens into options and console commands. An option takes the following token
+// as its value; a console command takes every following token up to the next option or console command, as SRCDS
+// does. Tokens that pre-join a name and value (e.g. "-game csgo") are also accepted.
+func SplitLaunchArgs(argv []string) ([]LaunchArg, error) {
+	var r []LaunchArg
+
+	for i := 0; i < len(argv); i++ {
+		token := argv[i]
+
+		if len(token) < 2 || (token[0] != '-' && token[0] != '+') {
+			return nil, fmt.Errorf("Unexpected command line token %q; expected an option or console command", token)
+		}
+
+		a := LaunchArg{Prefix: token[0], Name: token[1:]}
+		if j := strings.IndexAny(a.Name, " \t"); j >= 0 {
+			a.Name, a.Value, a.HasValue = a.Name[:j], strings.TrimSpace(a.Name[j+1:]), true
+			a.Value = strings.Trim(a.Value, `"`)
+		}
+
+		var values []string
+		for i+1 < len(argv) && !isLaunchArgName(argv[i+1]) {
+			values = append(values, argv[i+1])
+			i++
+
+			if a.Prefix == '-' {
+				break
+			}
+		}
+
+		if len(values) > 0 {
+			if a.HasValue {
+				return nil, fmt.Errorf("Command line argument %q already has a value", token)
+			}
+
+			a.Value, a.HasValue = strings.Join(values, " "), true
+		}
+
+		r = append(r, a)
+	}
+
+	return r, nil
+}
+
+// isLaunchArgName determines if a command line token starts an option or console command; negative numbers are values
+func isLaunchArgName(token string) bool {
+	if len(token) < 2 || (token[0] != '-' && token[0] != '+') {
+		return false
+	}
+
+	return !(token[1] >= '0' && token[1] <= '9') && token[1] != '.'
+}
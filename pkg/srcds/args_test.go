@@ -0,0 +1,48 @@
+package srcds
+
+import (
+	"testing"
+)
+
+func Test_SplitLaunchArgs(t *testing.T) {
+	argv := []string{"-game", "csgo", "-insecure", "+hostname", "Laclede's", "LAN", "+sv_buy_status_override", "-1", "-port 27015"}
+
+	expected := []LaunchArg{
+		{Prefix: '-', Name: "game", Value: "csgo", HasValue: true},
+		{Prefix: '-', Name: "insecure"},
+		{Prefix: '+', Name: "hostname", Value: "Laclede's LAN", HasValue: true},
+		{Prefix: '+', Name: "sv_buy_status_override", Value: "-1", HasValue: true},
+		{Prefix: '-', Name: "port", Value: "27015", HasValue: true},
+	}
+
+	actual, err := SplitLaunchArgs(argv)
+	if err != nil {
+		t.Fatalf("Arguments %q should have split: %v", argv, err)
+	}
+
+	if len(actual) != len(expected) {
+		t.Fatalf("Expected arguments %+v not %+v.", expected, actual)
+	}
+
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("Expected argument %+v not %+v.", expected[i], actual[i])
+		}
+	}
+
+	invalidCases := [][]string{{"csgo"}, {"-port 27015", "27016"}}
+	for _, test := range invalidCases {
+		if _, err := SplitLaunchArgs(test); err == nil {
+			t.Errorf("Arguments %q should NOT have split.", test)
+		}
+	}
+}
+
+func Test_ParseArgs(t *testing.T) {
+	for _, test := range []Args{{}, {Insecure: true, Bots: true, NoRestart: true}, {NoRestart: true}} {
+		actual, rest, err := ParseArgs(test.AsSlice())
+		if err != nil || len(rest) > 0 || actual != test {
+			t.Errorf("Expected arguments %+v to round trip not %+v (%+v, %v).", test, actual, rest, err)
+		}
+	}
+}
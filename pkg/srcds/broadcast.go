@@ -0,0 +1,224 @@
+package srcds
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/rs/zerolog/log"
+)
+
+// OverflowPolicy determines what happens to log entries broadcast to a subscriber whose buffer is full
+type OverflowPolicy uint8
+
+const (
+	// BlockWhenFull waits for the subscriber to make room; a stalled subscriber stalls every subscriber and the observer
+	BlockWhenFull OverflowPolicy = iota
+	// DropOldest discards the oldest buffered log entry to make room for the newest
+	DropOldest
+	// DropNewest discards log entries that arrive while the buffer is full
+	DropNewest
+)
+
+func (p OverflowPolicy) String() string {
+	switch p {
+	case BlockWhenFull:
+		return "block"
+	case DropOldest:
+		return "drop_oldest"
+	case DropNewest:
+		return "drop_newest"
+	default:
+		return "unknown"
+	}
+}
+
+// Broadcaster fans a log stream out to many independent subscribers, each with its own buffer and overflow policy
+type Broadcaster struct {
+	closed      bool
+	mux         sync.Mutex
+	subscribers []*Subscription
+}
+
+// Subscription receives the log entries sent to a Broadcaster
+type Subscription struct {
+	dropped uint64 // accessed atomically
+	sent    uint64 // accessed atomically
+
+	broadcaster *Broadcaster
+	c           chan LogEntry
+	done        chan struct{}
+	doneOnce    sync.Once
+	name        string
+	policy      OverflowPolicy
+}
+
+// SubscriptionStats are the delivery metrics of a subscription
+type SubscriptionStats struct {
+	Name     string
+	Policy   string
+	Buffered int
+	Capacity int
+	Sent     uint64 // log entries added to the buffer, including any later discarded by DropOldest
+	Dropped  uint64
+}
+
+// NewBroadcaster for fanning out a log stream
+func NewBroadcaster() *Broadcaster {
+	return &Broadcaster{}
+}
+
+// Subscribe to the broadcast log stream; the name identifies the subscription in metrics. Subscribing after the
+// broadcaster closed returns a subscription whose stream is already closed.
+func (b *Broadcaster) Subscribe(name string, size int, policy OverflowPolicy) *Subscription {
+	if size < 1 && policy != BlockWhenFull {
+		// Dropping policies need somewhere to buffer at least one log entry
+		size = 1
+	}
+
+	if size < 0 {
+		size = 0
+	}
+
+	s := &Subscription{
+		broadcaster: b,
+		c:           make(chan LogEntry, size),
+		done:        make(chan struct{}),
+		name:        name,
+		policy:      policy,
+	}
+
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	if b.closed {
+		close(s.c)
+		return s
+	}
+
+	b.subscribers = append(b.subscribers, s)
+	log.Debug().Str("subscriber", name).Int("size", size).Msgf("Subscribed to the log stream (%v)", policy)
+
+	return s
+}
+
+// Broadcast a log entry to every subscriber
+func (b *Broadcaster) Broadcast(le LogEntry) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	for _, s := range b.subscribers {
+		s.send(le)
+	}
+}
+
+// Close the broadcaster and the streams of every subscriber
+func (b *Broadcaster) Close() {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	if b.closed {
+		return
+	}
+
+	b.closed = true
+	for _, s := range b.subscribers {
+		close(s.c)
+	}
+
+	b.subscribers = nil
+}
+
+// Stats returns the delivery metrics of every current subscriber
+func (b *Broadcaster) Stats() []SubscriptionStats {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	r := make([]SubscriptionStats, 0, len(b.subscribers))
+	for _, s := range b.subscribers {
+		r = append(r, s.Stats())
+	}
+
+	return r
+}
+
+// C returns the subscription's log stream; it is closed when the broadcaster closes or the subscriber unsubscribes
+func (s *Subscription) C() <-chan LogEntry {
+	return s.c
+}
+
+// Dropped returns the number of log entries discarded because the subscription's buffer was full
+func (s *Subscription) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// Stats returns the subscription's delivery metrics
+func (s *Subscription) Stats() SubscriptionStats {
+	return SubscriptionStats{
+		Name:     s.name,
+		Policy:   s.policy.String(),
+		Buffered: len(s.c),
+		Capacity: cap(s.c),
+		Sent:     atomic.LoadUint64(&s.sent),
+		Dropped:  atomic.LoadUint64(&s.dropped),
+	}
+}
+
+// Unsubscribe from the broadcaster, closing the subscription's log stream
+func (s *Subscription) Unsubscribe() {
+	// Release a broadcast blocked on this subscription before waiting on the broadcaster
+	s.doneOnce.Do(func() { close(s.done) })
+
+	b := s.broadcaster
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	for i, sub := range b.subscribers {
+		if sub == s {
+			b.subscribers = append(b.subscribers[:i], b.subscribers[i+1:]...)
+			close(s.c)
+			log.Debug().Str("subscriber", s.name).Msg("Unsubscribed from the log stream")
+			return
+		}
+	}
+}
+
+// send a log entry to the subscriber according to its overflow policy; the caller must hold the broadcaster's lock
+func (s *Subscription) send(le LogEntry) {
+	switch s.policy {
+	case DropNewest:
+		select {
+		case s.c <- le:
+			atomic.AddUint64(&s.sent, 1)
+		default:
+			s.drop()
+		}
+	case DropOldest:
+		for {
+			select {
+			case s.c <- le:
+				atomic.AddUint64(&s.sent, 1)
+				return
+			default:
+			}
+
+			select {
+			case <-s.c:
+				s.drop()
+			default:
+			}
+		}
+	default:
+		select {
+		case s.c <- le:
+			atomic.AddUint64(&s.sent, 1)
+		case <-s.done:
+		}
+	}
+}
+
+func (s *Subscription) drop() {
+	if n := atomic.AddUint64(&s.dropped, 1); n&(n-1) == 0 {
+		// Logged at powers of two to avoid flooding the log while a subscriber is stalled
+		log.Warn().Str("subscriber", s.name).Uint64("dropped", n).Msgf("Subscriber is falling behind; log entries dropped (%v)", s.policy)
+	}
+}
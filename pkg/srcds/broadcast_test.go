@@ -0,0 +1,117 @@
+package srcds
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_Broadcaster_policies(t *testing.T) {
+	sut := NewBroadcaster()
+
+	block := sut.Subscribe("block", 5, BlockWhenFull)
+	oldest := sut.Subscribe("oldest", 2, DropOldest)
+	newest := sut.Subscribe("newest", 2, DropNewest)
+
+	for i := 1; i <= 5; i++ {
+		sut.Broadcast(LogEntry{Message: strconv.Itoa(i)})
+	}
+	sut.Close()
+
+	tests := []struct {
+		sub      *Subscription
+		expected string
+		dropped  uint64
+	}{
+		{block, "12345", 0},
+		{oldest, "45", 3},
+		{newest, "12", 3},
+	}
+
+	for _, test := range tests {
+		actual := ""
+		for le := range test.sub.C() {
+			actual += le.Message
+		}
+
+		if actual != test.expected || test.sub.Dropped() != test.dropped {
+			t.Errorf("Subscriber %q expected %q with %d dropped not %q with %d dropped.", test.sub.name, test.expected, test.dropped, actual, test.sub.Dropped())
+		}
+	}
+
+	if _, open := <-sut.Subscribe("late", 1, DropNewest).C(); open {
+		t.Error("Subscribing to a closed broadcaster should return a closed stream.")
+	}
+}
+
+func Test_Broadcaster_unsubscribeReleasesBlockedBroadcast(t *testing.T) {
+	sut := NewBroadcaster()
+	stalled := sut.Subscribe("stalled", 0, BlockWhenFull)
+	other := sut.Subscribe("other", 1, BlockWhenFull)
+
+	done := make(chan struct{})
+	go func() {
+		sut.Broadcast(LogEntry{Message: "hello"})
+		close(done)
+	}()
+
+	stalled.Unsubscribe()
+	<-done
+
+	if le := <-other.C(); le.Message != "hello" {
+		t.Errorf("Expected the other subscriber to receive the log entry not %q.", le.Message)
+	}
+
+	stats := sut.Stats()
+	if len(stats) != 1 || stats[0].Name != "other" || stats[0].Sent != 1 || stats[0].Policy != "block" {
+		t.Errorf("Unexpected subscription stats %+v.", stats)
+	}
+
+	if _, open := <-stalled.C(); open {
+		t.Error("Unsubscribing should close the subscription's stream.")
+	}
+}
+
+func Test_Observer_stalledSubscriberDoesNotBlockParsing(t *testing.T) {
+	lines := []string{}
+	for i := 1; i <= 20; i++ {
+		lines = append(lines, "L 01/01/2000 - 10:11:12: entry "+strconv.Itoa(i))
+	}
+
+	sut := NewObserver()
+	stalled := sut.Subscribe("stalled", 2, DropNewest)
+	parsed := 0
+	if err := sut.RegisterParser(LineParser{Name: "count", Prefix: "entry ", Handler: func(LogEntry, []string) ParseAction {
+		parsed++
+		return ParseContinue
+	}}); err != nil {
+		t.Fatalf("Parser should have registered: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		sut.Read(strings.NewReader(strings.Join(lines, "\n") + "\n"))
+		sut.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("A stalled subscriber blocked the observer.")
+	}
+
+	if parsed != len(lines) || stalled.Dropped() != uint64(len(lines)-2) {
+		t.Errorf("Expected %d log entries parsed with %d dropped not %d with %d dropped.", len(lines), len(lines)-2, parsed, stalled.Dropped())
+	}
+
+	received := 0
+	for range stalled.C() {
+		received++
+	}
+
+	if received != 2 {
+		t.Errorf("Expected the stalled subscriber to have buffered 2 log entries not %d.", received)
+	}
+}
@@ -0,0 +1,347 @@
+package srcds
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	configMaxExecDepth  = 8
+	configMaxAliasDepth = 8
+)
+
+// ConfigCommand is a single command from a .cfg file
+type ConfigCommand struct {
+	File string
+	Line int
+	Name string
+	Args []string
+}
+
+// String formats the command for sending to SRCDS
+func (c ConfigCommand) String() string {
+	var sb strings.Builder
+	sb.WriteString(c.Name)
+
+	for _, arg := range c.Args {
+		sb.WriteString(" ")
+
+		if len(arg) == 0 || strings.ContainsAny(arg, " \t;/") {
+			sb.WriteString(`"` + arg + `"`)
+		} else {
+			sb.WriteString(arg)
+		}
+	}
+
+	return sb.String()
+}
+
+// isCvarAssignment determines if the command sets a cvar whose value can be verified
+func (c ConfigCommand) isCvarAssignment() bool {
+	return len(c.Args) == 1 && !isConfigCommand(c.Name) && !strings.HasPrefix(c.Name, "+") && !strings.HasPrefix(c.Name, "-")
+}
+
+// Config is a parsed .cfg file; exec'd files are inlined and aliases are expanded where they are invoked
+type Config struct {
+	Commands []ConfigCommand
+	Aliases  map[string]string
+}
+
+// Cvars returns the value each cvar is left with once every command in the config has been applied
+func (c Config) Cvars() map[string]string {
+	r := make(map[string]string)
+
+	for _, cmd := range c.Commands {
+		if _, isAlias := c.Aliases[cmd.Name]; !isAlias && cmd.isCvarAssignment() {
+			r[cmd.Name] = cmd.Args[0]
+		}
+	}
+
+	return r
+}
+
+// ConfigMismatch is a cvar that didn't take on the value set by a config
+type ConfigMismatch struct {
+	Name     string
+	Expected string
+	Observed string
+	Reported bool // false if the server didn't report a value while verifying (e.g. an unknown cvar)
+}
+
+// LoadConfig parses a .cfg file; files it execs (including nested execs) are resolved relative to its directory, as
+// SRCDS resolves them relative to the cfg directory
+func LoadConfig(path string) (Config, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("Couldn't open config %q: %w", path, err)
+	}
+	defer f.Close()
+
+	dir := filepath.Dir(path)
+	p := configParser{
+		config: Config{Aliases: make(map[string]string)},
+		resolve: func(name string) (io.ReadCloser, error) {
+			if filepath.Ext(name) == "" {
+				name += ".cfg"
+			}
+
+			return os.Open(filepath.Join(dir, filepath.FromSlash(name)))
+		},
+		executing: map[string]bool{filepath.Base(path): true},
+	}
+
+	if err := p.parse(f, filepath.Base(path), 0); err != nil {
+		return Config{}, err
+	}
+
+	return p.config, nil
+}
+
+// isConfigCommand determines if the name is a command taking a single argument rather than a cvar; the values of
+// commands can't be verified
+func isConfigCommand(name string) bool {
+	switch strings.ToLower(name) {
+	case "alias", "ban", "banid", "banip", "bot_add", "bot_kick", "changelevel", "echo", "exec", "host_workshop_collection",
+		"host_workshop_map", "kick", "kickid", "log", "logaddress_add", "logaddress_del", "map", "mp_backup_restore_load_file",
+		"mp_pause_match", "mp_restartgame", "mp_unpause_match", "mp_warmup_end", "mp_warmup_start", "removeid", "say", "say_team",
+		"tv_record", "tv_stoprecord", "wait", "writeid", "writeip":
+		return true
+	default:
+		return false
+	}
+}
+
+// ParseConfig parses a .cfg file's contents; exec commands are kept as-is as there is nowhere to resolve them from
+func ParseConfig(r io.Reader, name string) (Config, error) {
+	p := configParser{config: Config{Aliases: make(map[string]string)}}
+
+	if err := p.parse(r, name, 0); err != nil {
+		return Config{}, err
+	}
+
+	return p.config, nil
+}
+
+type configParser struct {
+	config    Config
+	executing map[string]bool // files currently being parsed; guards against exec loops
+	resolve   func(name string) (io.ReadCloser, error)
+}
+
+func (p *configParser) parse(r io.Reader, file string, depth int) error {
+	scanner := bufio.NewScanner(r)
+
+	for line := 1; scanner.Scan(); line++ {
+		for _, tokens := range splitConfigLine(scanner.Text()) {
+			cmd := ConfigCommand{File: file, Line: line, Name: tokens[0], Args: tokens[1:]}
+
+			if err := p.apply(cmd, depth, 0); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("Couldn't read config %q: %w", file, err)
+	}
+
+	return nil
+}
+
+// apply adds a command to the config, following exec commands and expanding aliases
+func (p *configParser) apply(cmd ConfigCommand, execDepth, aliasDepth int) error {
+	name := strings.ToLower(cmd.Name)
+
+	if name == "alias" && len(cmd.Args) > 0 {
+		p.config.Aliases[cmd.Args[0]] = strings.Join(cmd.Args[1:], " ")
+		p.config.Commands = append(p.config.Commands, cmd)
+		return nil
+	}
+
+	if body, isAlias := p.config.Aliases[cmd.Name]; isAlias {
+		if aliasDepth >= configMaxAliasDepth {
+			return fmt.Errorf("%v:%d: alias %q is nested too deeply", cmd.File, cmd.Line, cmd.Name)
+		}
+
+		for _, tokens := range splitConfigLine(body) {
+			expanded := ConfigCommand{File: cmd.File, Line: cmd.Line, Name: tokens[0], Args: tokens[1:]}
+			if err := p.apply(expanded, execDepth, aliasDepth+1); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if name == "exec" && len(cmd.Args) > 0 && p.resolve != nil {
+		return p.exec(cmd, execDepth)
+	}
+
+	p.config.Commands = append(p.config.Commands, cmd)
+	return nil
+}
+
+func (p *configParser) exec(cmd ConfigCommand, depth int) error {
+	target := cmd.Args[0]
+	if filepath.Ext(target) == "" {
+		target += ".cfg"
+	}
+
+	if depth >= configMaxExecDepth {
+		return fmt.Errorf("%v:%d: exec of %q is nested too deeply", cmd.File, cmd.Line, target)
+	}
+
+	if p.executing[target] {
+		return fmt.Errorf("%v:%d: exec of %q would loop", cmd.File, cmd.Line, target)
+	}
+
+	rc, err := p.resolve(target)
+	if err != nil {
+		return fmt.Errorf("%v:%d: couldn't exec %q: %w", cmd.File, cmd.Line, target, err)
+	}
+	defer rc.Close()
+
+	p.executing[target] = true
+	defer delete(p.executing, target)
+
+	return p.parse(rc, target, depth+1)
+}
+
+// splitConfigLine splits a line of a .cfg file into commands and their tokens; semicolons separate commands, double
+// quotes group tokens, and // starts a comment unless quoted
+func splitConfigLine(line string) [][]string {
+	var commands [][]string
+	var tokens []string
+	var token strings.Builder
+	inQuotes, hasToken := false, false
+
+	endToken := func() {
+		if hasToken {
+			tokens = append(tokens, token.String())
+		}
+		token.Reset()
+		hasToken = false
+	}
+
+	endCommand := func() {
+		endToken()
+		if len(tokens) > 0 {
+			commands = append(commands, tokens)
+		}
+		tokens = nil
+	}
+
+	for i := 0; i < len(line); i++ {
+		ch := line[i]
+
+		switch {
+		case ch == '"':
+			if inQuotes {
+				endToken()
+			} else {
+				endToken()
+				hasToken = true
+			}
+			inQuotes = !inQuotes
+		case inQuotes:
+			token.WriteByte(ch)
+		case ch == '/' && i+1 < len(line) && line[i+1] == '/':
+			endCommand()
+			return commands
+		case ch == ';':
+			endCommand()
+		case ch == ' ' || ch == '\t' || ch == '\r':
+			endToken()
+		default:
+			token.WriteByte(ch)
+			hasToken = true
+		}
+	}
+
+	endCommand()
+	return commands
+}
+
+// ApplyConfig sends every command in the config to the SRCDS instance; false if SRCDS stopped before they were all sent
+func (s *Server) ApplyConfig(c Config) bool {
+	log.Info().Int("commands", len(c.Commands)).Msg("Applying config")
+
+	for _, cmd := range c.Commands {
+		if !s.QueueCommand(cmd.String()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// VerifyConfig asks the SRCDS instance for the value of every cvar the config sets, waiting up to the timeout for
+// each to match; returns the cvars that didn't. Verification stops early if SRCDS stops.
+func (s *Server) VerifyConfig(c Config, timeout time.Duration) []ConfigMismatch {
+	expected := c.Cvars()
+
+	// Only values reported once verification starts count; log timestamps are only precise to the second
+	since := time.Now().Truncate(time.Second)
+
+	for name := range expected {
+		s.AddCvarWatcher(name)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for name := range expected {
+		if !s.QueueCommand(name) {
+			// Nothing more will be reported
+			deadline = time.Now()
+			break
+		}
+	}
+
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		mismatches := s.configMismatches(expected, since)
+		if len(mismatches) == 0 || !time.Now().Before(deadline) {
+			for _, m := range mismatches {
+				log.Warn().Str("cvar", m.Name).Str("expected", m.Expected).Str("observed", m.Observed).Bool("reported", m.Reported).
+					Msg("Config setting failed to apply")
+			}
+
+			return mismatches
+		}
+
+		<-ticker.C
+	}
+}
+
+// ApplyAndVerifyConfig applies the config then verifies it, returning the cvars that failed to apply
+func (s *Server) ApplyAndVerifyConfig(c Config, timeout time.Duration) []ConfigMismatch {
+	// Verification stops early on its own if SRCDS stopped while applying
+	s.ApplyConfig(c)
+	return s.VerifyConfig(c, timeout)
+}
+
+// configMismatches compares the expected cvars to the values the server reported since the given time; values that
+// were seeded or reported earlier may not reflect the config
+func (s *Server) configMismatches(expected map[string]string, since time.Time) []ConfigMismatch {
+	var r []ConfigMismatch
+
+	for name, value := range expected {
+		observed, reported := s.cvars.reportedSince(name, since)
+		if !reported || !cvarValuesEqual(value, observed) {
+			r = append(r, ConfigMismatch{Name: name, Expected: value, Observed: observed, Reported: reported})
+		}
+	}
+
+	sort.Slice(r, func(i, j int) bool { return r[i].Name < r[j].Name })
+
+	return r
+}
@@ -0,0 +1,223 @@
+package srcds
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_splitConfigLine(t *testing.T) {
+	validCases := map[string][][]string{
+		`mp_maxrounds 30`:                        {{"mp_maxrounds", "30"}},
+		`mp_maxrounds 30 // MR15`:                {{"mp_maxrounds", "30"}},
+		`hostname "Laclede's LAN // Tournament"`: {{"hostname", "Laclede's LAN // Tournament"}},
+		`mp_friendlyfire 0; sv_cheats 0`:         {{"mp_friendlyfire", "0"}, {"sv_cheats", "0"}},
+		`alias "go" "mp_warmup_end; say live"`:   {{"alias", "go", "mp_warmup_end; say live"}},
+		`mp_ct_default_secondary ""`:             {{"mp_ct_default_secondary", ""}},
+		"\tsv_tags\t\"lan,tournament\"\r":        {{"sv_tags", "lan,tournament"}},
+		`// just a comment`:                      nil,
+		`;;`:                                     nil,
+	}
+
+	for input, expected := range validCases {
+		actual := splitConfigLine(input)
+
+		if len(actual) != len(expected) {
+			t.Errorf("Expected %q to split into %q not %q.", input, expected, actual)
+			continue
+		}
+
+		for i := range expected {
+			if strings.Join(actual[i], "|") != strings.Join(expected[i], "|") || len(actual[i]) != len(expected[i]) {
+				t.Errorf("Expected %q to split into %q not %q.", input, expected, actual)
+			}
+		}
+	}
+}
+
+func Test_LoadConfig(t *testing.T) {
+	sut, err := LoadConfig("./testdata/cfg/tournament.cfg")
+	if err != nil {
+		t.Fatalf("The config should have loaded: %v", err)
+	}
+
+	expectedCommands := []string{
+		`hostname "Laclede's LAN // Tournament"`,
+		`mp_maxrounds 30`,
+		`mp_friendlyfire 0`,
+		`sv_cheats 0`,
+		`alias ff_on "mp_friendlyfire 1; mp_teammates_are_enemies 0"`,
+		`tv_enable 1`,
+		`tv_delay 90`,
+		`mp_friendlyfire 1`,
+		`mp_teammates_are_enemies 0`,
+		`say "Config loaded; good luck"`,
+	}
+
+	if len(sut.Commands) != len(expectedCommands) {
+		t.Fatalf("Expected commands %q not %+v.", expectedCommands, sut.Commands)
+	}
+
+	for i, expected := range expectedCommands {
+		if actual := sut.Commands[i].String(); actual != expected {
+			t.Errorf("Expected command %q not %q.", expected, actual)
+		}
+	}
+
+	if c := sut.Commands[6]; c.File != "shared/gotv.cfg" || c.Line != 2 {
+		t.Errorf("Expected tv_delay to be attributed to shared/gotv.cfg:2 not %v:%d.", c.File, c.Line)
+	}
+
+	cvars := sut.Cvars()
+	if len(cvars) != 7 || cvars["mp_friendlyfire"] != "1" || cvars["tv_delay"] != "90" || cvars["hostname"] != "Laclede's LAN // Tournament" {
+		t.Errorf("Unexpected cvars %v.", cvars)
+	}
+
+	if _, err := LoadConfig("./testdata/cfg/loop.cfg"); err == nil {
+		t.Error("A config that execs itself should NOT have loaded.")
+	}
+}
+
+func Test_Server_VerifyConfig(t *testing.T) {
+	c, err := ParseConfig(strings.NewReader("mp_maxrounds 30\nsv_cheats 0\nmp_not_a_cvar 1\n"), "inline.cfg")
+	if err != nil {
+		t.Fatalf("The config should have parsed: %v", err)
+	}
+
+	sut := NewServer()
+
+	// Stand in for SRCDS by answering cvar queries
+	go func() {
+		for cmd := range sut.cmdIn {
+			switch cmd {
+			case "mp_maxrounds":
+				sut.cvars.setIfWatchedFrom("mp_maxrounds", "30", time.Now(), CvarSourceConsole)
+			case "sv_cheats":
+				sut.cvars.setIfWatchedFrom("sv_cheats", "1", time.Now(), CvarSourceConsole)
+			}
+		}
+	}()
+
+	actual := sut.ApplyAndVerifyConfig(c, 200*time.Millisecond)
+	close(sut.cmdIn)
+
+	expected := []ConfigMismatch{
+		{Name: "mp_not_a_cvar", Expected: "1"},
+		{Name: "sv_cheats", Expected: "0", Observed: "1", Reported: true},
+	}
+
+	if len(actual) != len(expected) {
+		t.Fatalf("Expected mismatches %+v not %+v.", expected, actual)
+	}
+
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("Expected mismatch %+v not %+v.", expected[i], actual[i])
+		}
+	}
+}
+
+func Test_Server_VerifyConfig_consoleResponses(t *testing.T) {
+	c, err := ParseConfig(strings.NewReader("hostname \"Laclede's LAN // Tournament\"\nmp_maxrounds 30\nsv_cheats 0\n"), "inline.cfg")
+	if err != nil {
+		t.Fatalf("The config should have parsed: %v", err)
+	}
+
+	sut := NewServer()
+
+	// Stand in for SRCDS by answering cvar queries the way its console does
+	go func() {
+		for cmd := range sut.cmdIn {
+			switch cmd {
+			case "hostname":
+				sut.processMessage(`"hostname" = "Laclede's LAN // Tournament"`, nil)
+			case "mp_maxrounds":
+				sut.processMessage(`"mp_maxrounds" = "30" ( def. "0" ) min. 0.000000 game notify replicated          - max number of rounds to play before server changes maps`, nil)
+			case "sv_cheats":
+				sut.processMessage(`"sv_cheats" = "1" ( def. "0" ) notify replicated                    - Allow cheats on server`, nil)
+			}
+		}
+	}()
+
+	actual := sut.VerifyConfig(c, 200*time.Millisecond)
+	close(sut.cmdIn)
+
+	expected := []ConfigMismatch{{Name: "sv_cheats", Expected: "0", Observed: "1", Reported: true}}
+
+	if len(actual) != len(expected) {
+		t.Fatalf("Expected mismatches %+v not %+v.", expected, actual)
+	}
+
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("Expected mismatch %+v not %+v.", expected[i], actual[i])
+		}
+	}
+}
+
+func Test_Server_VerifyConfig_ignoresEarlierValues(t *testing.T) {
+	c, err := ParseConfig(strings.NewReader("mp_maxrounds 30\nmp_freezetime 15\nsv_cheats 0\n"), "inline.cfg")
+	if err != nil {
+		t.Fatalf("The config should have parsed: %v", err)
+	}
+
+	sut := NewServer()
+
+	// Seeded (as csgo seeds the rules) and previously reported values match the config but were never reported after it
+	sut.AddCvarWatcherDefault("mp_maxrounds", "30")
+	sut.AddCvarWatcher("mp_freezetime")
+	sut.cvars.setIfWatchedFrom("mp_freezetime", "15", time.Now().Add(-time.Hour), CvarSourceLog)
+
+	// Stand in for SRCDS by only answering some cvar queries
+	go func() {
+		for cmd := range sut.cmdIn {
+			if cmd == "sv_cheats" {
+				sut.cvars.setIfWatchedFrom("sv_cheats", "0", time.Now(), CvarSourceConsole)
+			}
+		}
+	}()
+
+	actual := sut.VerifyConfig(c, 200*time.Millisecond)
+	close(sut.cmdIn)
+
+	expected := []ConfigMismatch{
+		{Name: "mp_freezetime", Expected: "15"},
+		{Name: "mp_maxrounds", Expected: "30"},
+	}
+
+	if len(actual) != len(expected) {
+		t.Fatalf("Expected mismatches %+v not %+v.", expected, actual)
+	}
+
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("Expected mismatch %+v not %+v.", expected[i], actual[i])
+		}
+	}
+}
+
+func Test_Server_ApplyAndVerifyConfig_stopped(t *testing.T) {
+	c, err := ParseConfig(strings.NewReader("mp_maxrounds 30\nsv_cheats 0\n"), "inline.cfg")
+	if err != nil {
+		t.Fatalf("The config should have parsed: %v", err)
+	}
+
+	sut := NewServer()
+	sut.health.exit(time.Now(), nil)
+
+	if sut.ApplyConfig(c) {
+		t.Error("Expected the config NOT to be applied once the server stopped.")
+	}
+
+	done := make(chan []ConfigMismatch)
+	go func() { done <- sut.ApplyAndVerifyConfig(c, time.Minute) }()
+
+	select {
+	case actual := <-done:
+		if len(actual) != 2 {
+			t.Errorf("Expected both cvars to be mismatches not %+v.", actual)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Verifying a config blocked after the server stopped.")
+	}
+}
@@ -0,0 +1,113 @@
+package srcds
+
+import (
+	"github.com/rs/zerolog/log"
+)
+
+// ConsoleSeverity classifies how serious a line of console output is
+type ConsoleSeverity uint8
+
+const (
+	// ConsoleInfo is ordinary output such as command responses and download progress
+	ConsoleInfo ConsoleSeverity = iota
+	// ConsoleWarning is output that may indicate a problem
+	ConsoleWarning
+	// ConsoleError is output indicating something failed while the server kept running
+	ConsoleError
+	// ConsoleFatal is output indicating the server crashed or is about to
+	ConsoleFatal
+)
+
+func (s ConsoleSeverity) String() string {
+	switch s {
+	case ConsoleInfo:
+		return "info"
+	case ConsoleWarning:
+		return "warning"
+	case ConsoleError:
+		return "error"
+	case ConsoleFatal:
+		return "fatal"
+	default:
+		return "unknown"
+	}
+}
+
+// ConsoleOutput is a line of non-log console output along with its classification
+type ConsoleOutput struct {
+	ConsoleLine
+	Severity ConsoleSeverity
+	Category string // e.g. host_error, crash, load_failure, or workshop; empty for unclassified output
+}
+
+// ClassifyConsoleLine determines the severity and category of a line of console output
+func ClassifyConsoleLine(line ConsoleLine) ConsoleOutput {
+	severity, category, _ := parseConsoleSeverity(line.Text)
+	return ConsoleOutput{ConsoleLine: line, Severity: severity, Category: category}
+}
+
+// OnConsoleOutput registers a handler to be called with every classified line of non-log console output at or above
+// the minimum severity; handlers must be registered before the stream is observed
+func (o *Observer) OnConsoleOutput(minimum ConsoleSeverity, h func(ConsoleOutput)) {
+	if h == nil {
+		return
+	}
+
+	o.consoleOutputHandlers = append(o.consoleOutputHandlers, consoleOutputHandler{minimum: minimum, handler: h})
+}
+
+type consoleOutputHandler struct {
+	minimum ConsoleSeverity
+	handler func(ConsoleOutput)
+}
+
+// ConsoleOutputStream returns a stream of classified console output at or above the minimum severity; the stream is
+// closed once the log stream ends and must be drained as the observer blocks while it is full. Streams must be
+// requested before the log stream is observed.
+func (o *Observer) ConsoleOutputStream(minimum ConsoleSeverity, size int) <-chan ConsoleOutput {
+	c := make(chan ConsoleOutput, size)
+	o.consoleStreams = append(o.consoleStreams, c)
+
+	o.OnConsoleOutput(minimum, func(out ConsoleOutput) {
+		c <- out
+	})
+
+	return c
+}
+
+// closeConsoleStreams closes the console output streams as the log stream ends
+func (o *Observer) closeConsoleStreams() {
+	for _, c := range o.consoleStreams {
+		close(c)
+	}
+
+	o.consoleStreams = nil
+}
+
+// processConsoleLine classifies a line of non-log console output once and passes it to every handler
+func (o *Observer) processConsoleLine(line ConsoleLine) {
+	out := ClassifyConsoleLine(line)
+	logConsoleOutput(out)
+
+	for _, h := range o.consoleHandlers {
+		h(line)
+	}
+
+	for _, h := range o.consoleOutputHandlers {
+		if out.Severity >= h.minimum {
+			h.handler(out)
+		}
+	}
+}
+
+// logConsoleOutput surfaces problems reported on the console in the observer's own logging
+func logConsoleOutput(out ConsoleOutput) {
+	switch out.Severity {
+	case ConsoleFatal:
+		log.Error().Str("category", out.Category).Msgf("SRCDS: %s", out.Text)
+	case ConsoleError, ConsoleWarning:
+		log.Warn().Str("category", out.Category).Msgf("SRCDS: %s", out.Text)
+	default:
+		log.Debug().Str("category", out.Category).Msgf("SRCDS: %s", out.Text)
+	}
+}
@@ -0,0 +1,68 @@
+package srcds
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_ClassifyConsoleLine(t *testing.T) {
+	validCases := []struct {
+		text     string
+		severity ConsoleSeverity
+		category string
+	}{
+		{`Host_Error: Couldn't allocate any server IP port`, ConsoleFatal, "host_error"},
+		{`./srcds_run: line 380: 1234 Segmentation fault (core dumped) $HL_CMD`, ConsoleFatal, "crash"},
+		{`FATAL ERROR: Unable to initialize Steam`, ConsoleFatal, "fatal_error"},
+		{`Failed to load the launcher (bin/dedicated.so)`, ConsoleError, "load_failure"},
+		{`Couldn't load map de_missing`, ConsoleError, "load_failure"},
+		{`Unknown command "mp_warmup_ennd"`, ConsoleWarning, "unknown_command"},
+		{`ERROR! Failed to find material "dev/dev_measuregeneric01"`, ConsoleError, "error"},
+		{`WARNING: Port 27015 was unavailable`, ConsoleWarning, "warning"},
+		{`Workshop: downloading map 123456789 (45%)`, ConsoleInfo, "workshop"},
+		{`Listing round backup files`, ConsoleInfo, ""},
+		{`"sv_showerrors" = "0" ( def. "0" ) - Show errors when players fail to connect`, ConsoleInfo, ""},
+		{`Trying to recover from an error...`, ConsoleInfo, ""},
+	}
+
+	for _, test := range validCases {
+		actual := ClassifyConsoleLine(ConsoleLine{Text: test.text})
+		if actual.Severity != test.severity || actual.Category != test.category || actual.Text != test.text {
+			t.Errorf("Expected %q to be classified as %v/%q not %v/%q.", test.text, test.severity, test.category, actual.Severity, actual.Category)
+		}
+	}
+}
+
+func Test_Observer_ConsoleOutputStream(t *testing.T) {
+	stream := strings.Join([]string{
+		`Workshop: downloading map 123456789`,
+		`L 01/01/2000 - 10:11:12: Start the ship, Leela!`,
+		`Host_Error: something went wrong`,
+		`WARNING: Port 27015 was unavailable`,
+	}, "\n") + "\n"
+
+	sut := NewObserver()
+	c := sut.ConsoleOutputStream(ConsoleWarning, 4)
+	sut.Read(strings.NewReader(stream))
+
+	actual := []ConsoleOutput{}
+	for out := range c {
+		actual = append(actual, out)
+	}
+	sut.Wait()
+
+	expected := []ConsoleOutput{
+		{ConsoleLine: ConsoleLine{Text: `Host_Error: something went wrong`, LogEntriesBefore: 1}, Severity: ConsoleFatal, Category: "host_error"},
+		{ConsoleLine: ConsoleLine{Text: `WARNING: Port 27015 was unavailable`, LogEntriesBefore: 1}, Severity: ConsoleWarning, Category: "warning"},
+	}
+
+	if len(actual) != len(expected) {
+		t.Fatalf("Expected console output %+v not %+v.", expected, actual)
+	}
+
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("Expected console output %+v not %+v.", expected[i], actual[i])
+		}
+	}
+}
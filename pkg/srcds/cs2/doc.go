@@ -0,0 +1,6 @@
+/*
+Package cs2 provides functionality for tailing, parsing, and interpreting <a href="https://www.counter-strike.net/cs2">Counter-Strike 2</a> dedicated server logging along with constructs
+for automating wrapped, executing cs2 server processes. CS2 logs in the same format as CSGO so the csgo package does the
+interpreting, playing by CS2's rules.
+*/
+package cs2
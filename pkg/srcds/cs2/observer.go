@@ -0,0 +1,31 @@
+package cs2
+
+import (
+	"github.com/lacledeslan/sourceseer/pkg/srcds/csgo"
+)
+
+// CS2 plays MR12 where CSGO played MR15; the remaining competitive rules are unchanged
+const (
+	defaultMpMaxrounds         int = 24
+	defaultMpOvertimeMaxrounds int = 6
+)
+
+// NewObserver for observing CS2 log streams; rounds less than one fall back to CS2's competitive defaults
+func NewObserver(mpHalftime, mpMaxRounds, mpMaxOvertimeRounds int) *Observer {
+	if mpMaxRounds < 1 {
+		mpMaxRounds = defaultMpMaxrounds
+	}
+
+	if mpMaxOvertimeRounds < 1 {
+		mpMaxOvertimeRounds = defaultMpOvertimeMaxrounds
+	}
+
+	return &Observer{
+		Observer: csgo.NewObserver(mpHalftime, mpMaxRounds, mpMaxOvertimeRounds),
+	}
+}
+
+// Observer for watching CS2 log streams
+type Observer struct {
+	*csgo.Observer
+}
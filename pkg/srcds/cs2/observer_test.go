@@ -0,0 +1,145 @@
+package cs2
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+	"github.com/lacledeslan/sourceseer/pkg/srcds/csgo"
+)
+
+func Test_Observer_mpTeam1Win(t *testing.T) {
+	file, err := os.Open("./testdata/mpteam1_mr12_win.log")
+	if err != nil {
+		t.Fatalf("Could not open log file: %v", err)
+	}
+	defer file.Close()
+
+	accolades, discrepancies, halftimes, points := []csgo.Accolade{}, 0, []int{}, []csgo.MatchPoint{}
+	decided := []csgo.MatchDecided{}
+
+	sut := NewObserver(1, 24, 6)
+	sut.OnEvent(func(e srcds.Event) {
+		switch v := e.(type) {
+		case csgo.Accolade:
+			accolades = append(accolades, v)
+		case csgo.Halftime:
+			halftimes = append(halftimes, v.Round)
+		case csgo.MatchDecided:
+			decided = append(decided, v)
+		case csgo.MatchPoint:
+			points = append(points, v)
+		case csgo.ScoreDiscrepancy:
+			discrepancies++
+		}
+	})
+	sut.Read(file)
+	sut.Wait()
+
+	if discrepancies != 0 {
+		t.Errorf("Expected the computed scores to agree with the server but there were %d discrepancies.", discrepancies)
+	}
+
+	if len(halftimes) != 1 || halftimes[0] != 12 {
+		t.Errorf("Expected halftime after round 12 not %v.", halftimes)
+	}
+
+	if len(points) != 1 || points[0].Round != 18 || points[0].TeamName != "Laclede" {
+		t.Errorf("Expected a single match point for Laclede in round 18 not %+v.", points)
+	}
+
+	if len(decided) != 1 || decided[0].Winner != "mp_team1" || decided[0].Team1Score != 13 || decided[0].Team2Score != 5 {
+		t.Fatalf("Expected mp_team1 to win 13-5 not %+v.", decided)
+	}
+
+	if len(accolades) != 2 || accolades[0].Name != "mvp" || accolades[0].Username != "Alpha" || !accolades[0].Final {
+		t.Errorf("Expected the final accolades to be emitted not %+v.", accolades)
+	}
+
+	if team1, team2 := sut.TeamNames(); team1 != "Laclede" || team2 != "Orange" {
+		t.Errorf("Expected team names %q and %q not %q and %q.", "Laclede", "Orange", team1, team2)
+	}
+
+	m, ok := sut.CurrentMatch()
+	if !ok {
+		t.Fatal("Expected a match to have been observed.")
+	}
+
+	if m.MapName != "de_ancient" || len(m.Rounds) != 18 || !m.Decided || m.Winner != "mp_team1" || m.Ended.IsZero() {
+		t.Errorf("Unexpected match snapshot %+v.", m)
+	}
+
+	if m.Rounds[12].WinningAffiliation != "TERRORIST" || m.Rounds[12].WinningTeam != "mp_team1" {
+		t.Errorf("Expected mp_team1 to win round 13 as the terrorists not %+v.", m.Rounds[12])
+	}
+
+	if p := m.Players["U:1:100001"]; p.Username != "Alpha" || p.Kills != 18 || p.HeadshotKills != 18 || p.Deaths != 0 {
+		t.Errorf("Expected Alpha to have 18 headshot kills not %+v.", p)
+	}
+
+	if p := m.Players["U:1:100002"]; p.Username != "Bravo" || p.Kills != 0 || p.Deaths != 18 {
+		t.Errorf("Expected Bravo to have died 18 times not %+v.", p)
+	}
+}
+
+func Test_Observer_lateAttach(t *testing.T) {
+	discrepancies := 0
+
+	sut := NewObserver(1, 24, 6)
+	sut.OnEvent(func(e srcds.Event) {
+		if _, ok := e.(csgo.ScoreDiscrepancy); ok {
+			discrepancies++
+		}
+	})
+	sut.Read(strings.NewReader(strings.Join([]string{
+		`L 10/14/2023 - 18:00:00: World triggered "Round_Start"`,
+		`L 10/14/2023 - 18:00:40: Team "TERRORIST" triggered "SFUI_Notice_Terrorists_Win" (CT "4") (T "10")`,
+		`L 10/14/2023 - 18:00:40: MatchStatus: Score: 4:10 on map "de_ancient" RoundsPlayed: 14`,
+	}, "\n")))
+	sut.Wait()
+
+	if discrepancies != 1 {
+		t.Errorf("Expected the missed rounds to be reconciled once not %d times.", discrepancies)
+	}
+
+	m, _ := sut.CurrentMatch()
+	if len(m.Rounds) != 14 || !m.Rounds[0].Reconstructed || m.Rounds[13].Reconstructed {
+		t.Fatalf("Expected 13 reconstructed rounds followed by the observed round not %+v.", m.Rounds)
+	}
+
+	if m.Rounds[13].WinningTeam != "mp_team1" {
+		t.Errorf("Expected mp_team1 to have won round 14 as the terrorists not %q.", m.Rounds[13].WinningTeam)
+	}
+}
+
+func Test_Observer_roundStats(t *testing.T) {
+	sut := NewObserver(1, 24, 6)
+	sut.Read(strings.NewReader(strings.Join([]string{
+		`L 10/14/2023 - 18:00:00: World triggered "Match_Start" on "de_ancient"`,
+		`L 10/14/2023 - 18:00:10: World triggered "Round_Start"`,
+		`L 10/14/2023 - 18:00:40: Team "CT" triggered "SFUI_Notice_CTs_Win" (CT "1") (T "0")`,
+		`L 10/14/2023 - 18:00:40: JSON_BEGIN{`,
+		`L 10/14/2023 - 18:00:40: "name": "round_stats",`,
+		`L 10/14/2023 - 18:00:40: "round_number" : "1",`,
+		`L 10/14/2023 - 18:00:40: "score_t" : "0",`,
+		`L 10/14/2023 - 18:00:40: "score_ct" : "1",`,
+		`L 10/14/2023 - 18:00:40: "map" : "de_ancient",`,
+		`L 10/14/2023 - 18:00:40: "server" : "LAN",`,
+		`L 10/14/2023 - 18:00:40: "fields" : "             accountid,   team,  money,  kills, deaths",`,
+		`L 10/14/2023 - 18:00:40: "players" : {`,
+		`L 10/14/2023 - 18:00:40: "player_0" : "        100001,      3,   3250,      2,      0"`,
+		`L 10/14/2023 - 18:00:40: }}JSON_END`,
+		`L 10/14/2023 - 18:00:50: World triggered "Round_Start"`,
+	}, "\n")))
+	sut.Wait()
+
+	m, _ := sut.CurrentMatch()
+	if len(m.Rounds) != 1 || m.Rounds[0].Stats == nil {
+		t.Fatalf("Expected round stats to be attached to the first round not %+v.", m.Rounds)
+	}
+
+	if p := m.Rounds[0].Stats.Players; len(p) != 1 || p[0].SteamID != "U:1:100001" || p[0].Kills != 2 {
+		t.Errorf("Unexpected player round stats %+v.", p)
+	}
+}
@@ -0,0 +1,22 @@
+package cs2
+
+import (
+	"github.com/lacledeslan/sourceseer/pkg/srcds/csgo"
+)
+
+// NewServer for interacting with a CS2 SRCDS instance
+func NewServer() *Server {
+	s := &Server{
+		Server: csgo.NewServer(),
+	}
+
+	s.AddRuleDefault("mp_maxrounds", defaultMpMaxrounds)
+	s.AddRuleDefault("mp_overtime_maxrounds", defaultMpOvertimeMaxrounds)
+
+	return s
+}
+
+// Server represents an interactive CS2 SRCDS instance
+type Server struct {
+	*csgo.Server
+}
@@ -1,11 +1,19 @@
 package csgo
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/lacledeslan/sourceseer/pkg/srcds"
 )
 
+const (
+	// defaultMap and defaultTickRate must match the defaults of Args' struct tags
+	defaultMap      = "de_cbble"
+	defaultTickRate = 128
+)
+
 //Args contains the command line options for the Counter-Strike: Global Offensive Server executable
 type Args struct {
 	srcds.Args
@@ -25,50 +33,48 @@ type Args struct {
 	UseRemoteConsole bool   `long:"userrcon" description:"Enables Remove Console"`
 }
 
-//AsSlice returns the command line options stored in a slice with individual values properly formatted for SRCDS
+//AsSlice returns the command line options stored in a slice with individual values properly formatted for SRCDS; each
+//option, console command, and value is its own token
 func (o Args) AsSlice() []string {
-	r := append(o.Args.AsSlice(), "-game csgo", "+game_mode "+strconv.Itoa(o.GameMode), "+game_type "+strconv.Itoa(o.GameType), "+map "+o.Map)
+	r := append(o.Args.AsSlice(), "-game", "csgo", "+game_mode", strconv.Itoa(o.GameMode), "+game_type", strconv.Itoa(o.GameType), "+map", o.Map)
 
 	if o.Hostname != "" {
-		r = append(r, `+hostname "`+o.Hostname+`"`)
+		r = append(r, "+hostname", o.Hostname)
 	}
 
 	if o.TeamName1 != "" {
-		r = append(r, `+mp_teamname_1 "`+o.TeamName1+`"`)
+		r = append(r, "+mp_teamname_1", o.TeamName1)
 	}
 
 	if o.TeamName2 != "" {
-		r = append(r, `+mp_teamname_2 "`+o.TeamName2+`"`)
+		r = append(r, "+mp_teamname_2", o.TeamName2)
 	}
 
 	if o.LANMode != 0 {
-		r = append(r, "+sv_lan "+strconv.Itoa(o.LANMode))
+		r = append(r, "+sv_lan", strconv.Itoa(o.LANMode))
 	}
 
-	if len(o.RConPassword) > 0 && o.RConPassword == "0" {
-		r = append(r, `+rcon_password "`+o.RConPassword+`"`)
+	// An rcon password of 0 leaves rcon disabled
+	if o.RConPassword != "" && o.RConPassword != "0" {
+		r = append(r, "+rcon_password", o.RConPassword)
 	}
 
 	if o.Password != "" {
-		r = append(r, `+sv_password "`+o.Password+`"`)
+		r = append(r, "+sv_password", o.Password)
 	}
 
-	r = append(r, "-tickrate "+strconv.Itoa(o.TickRate))
+	r = append(r, "-tickrate", strconv.Itoa(o.TickRate))
 
 	if o.TVName != "" {
-		r = append(r, `+tv_name "`+o.TVName+`"`)
+		r = append(r, "+tv_name", o.TVName)
 	}
 
 	if o.TVPassword != "" {
-		r = append(r, `+tv_password "`+o.TVPassword+`"`)
-	}
-
-	if o.TVRelayPassword != "" {
-		r = append(r, `+tv_relaypassword "`+o.TVRelayPassword+`"`)
+		r = append(r, "+tv_password", o.TVPassword)
 	}
 
 	if o.TVRelayPassword != "" {
-		r = append(r, ` +tv_relaypassword "`+o.TVRelayPassword+`"`)
+		r = append(r, "+tv_relaypassword", o.TVRelayPassword)
 	}
 
 	if o.UseRemoteConsole {
@@ -77,3 +83,94 @@ func (o Args) AsSlice() []string {
 
 	return r
 }
+
+//Validate determines if the options can be passed to SRCDS
+func (o Args) Validate() error {
+	if o.TickRate != 64 && o.TickRate != 128 {
+		return fmt.Errorf("Tickrate must be 64 or 128 not %d", o.TickRate)
+	}
+
+	if o.LANMode != 0 && o.LANMode != 1 {
+		return fmt.Errorf("sv_lan must be 0 or 1 not %d", o.LANMode)
+	}
+
+	if o.GameMode < 0 || o.GameType < 0 {
+		return fmt.Errorf("game_type (%d) and game_mode (%d) cannot be negative", o.GameType, o.GameMode)
+	}
+
+	if !isMapName(o.Map) {
+		return fmt.Errorf("%q is not a valid map name", o.Map)
+	}
+
+	values := map[string]string{
+		"hostname": o.Hostname, "mp_teamname_1": o.TeamName1, "mp_teamname_2": o.TeamName2, "rcon_password": o.RConPassword,
+		"sv_password": o.Password, "tv_name": o.TVName, "tv_password": o.TVPassword, "tv_relaypassword": o.TVRelayPassword,
+	}
+
+	for name, v := range values {
+		// SRCDS would mistake the value for another option or console command
+		if strings.HasPrefix(v, "-") || strings.HasPrefix(v, "+") {
+			return fmt.Errorf("%v cannot start with - or +", name)
+		}
+
+		if strings.ContainsAny(v, "\"\r\n") {
+			return fmt.Errorf("%v cannot contain quotes or line breaks", name)
+		}
+	}
+
+	return nil
+}
+
+//ParseArgs reads the options of a CSGO SRCDS command line (excluding the executable) as produced by AsSlice; returns
+//the tokens of any arguments that aren't recognized
+func ParseArgs(argv []string) (Args, []string, error) {
+	srcdsArgs, rest, err := srcds.ParseArgs(argv)
+	if err != nil {
+		return Args{}, nil, err
+	}
+
+	// Options that AsSlice always includes default to their documented defaults; the rest are absent when empty
+	r := Args{Args: srcdsArgs, GameMode: defaultGameMode, GameType: defaultGameType, Map: defaultMap, TickRate: defaultTickRate}
+	var unrecognized []string
+
+	atoi := func(a srcds.LaunchArg) (int, error) {
+		i, err := strconv.Atoi(a.Value)
+		if err != nil {
+			return 0, fmt.Errorf("%c%v requires a number not %q", a.Prefix, a.Name, a.Value)
+		}
+
+		return i, nil
+	}
+
+	stringOptions := map[string]*string{
+		"hostname": &r.Hostname, "mp_teamname_1": &r.TeamName1, "mp_teamname_2": &r.TeamName2, "rcon_password": &r.RConPassword,
+		"sv_password": &r.Password, "map": &r.Map, "tv_name": &r.TVName, "tv_password": &r.TVPassword, "tv_relaypassword": &r.TVRelayPassword,
+	}
+
+	intOptions := map[string]*int{"game_mode": &r.GameMode, "game_type": &r.GameType, "sv_lan": &r.LANMode}
+
+	for _, a := range rest {
+		switch {
+		case a.Is('-', "game"):
+			if a.Value != "csgo" {
+				return Args{}, nil, fmt.Errorf("Expected -game csgo not %q", a.Value)
+			}
+		case a.Is('-', "tickrate"):
+			if r.TickRate, err = atoi(a); err != nil {
+				return Args{}, nil, err
+			}
+		case a.Is('-', "usercon") && !a.HasValue:
+			r.UseRemoteConsole = true
+		case a.Prefix == '+' && a.HasValue && stringOptions[a.Name] != nil:
+			*stringOptions[a.Name] = a.Value
+		case a.Prefix == '+' && intOptions[a.Name] != nil:
+			if *intOptions[a.Name], err = atoi(a); err != nil {
+				return Args{}, nil, err
+			}
+		default:
+			unrecognized = append(unrecognized, a.Tokens()...)
+		}
+	}
+
+	return r, unrecognized, nil
+}
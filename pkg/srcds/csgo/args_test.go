@@ -0,0 +1,144 @@
+package csgo
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+)
+
+func Test_Args_AsSlice(t *testing.T) {
+	sut := Args{
+		Args:            srcds.Args{Insecure: true},
+		GameMode:        1,
+		Map:             "de_inferno",
+		Hostname:        "Laclede's LAN",
+		LANMode:         1,
+		RConPassword:    "0",
+		Password:        "secret",
+		TickRate:        128,
+		TVRelayPassword: "relay",
+	}
+
+	expected := []string{"-insecure", "-nobots", "-game", "csgo", "+game_mode", "1", "+game_type", "0", "+map", "de_inferno",
+		"+hostname", "Laclede's LAN", "+sv_lan", "1", "+sv_password", "secret", "-tickrate", "128", "+tv_relaypassword", "relay"}
+
+	if actual := sut.AsSlice(); strings.Join(actual, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected arguments %q not %q.", expected, actual)
+	}
+
+	sut.RConPassword = "hunter2"
+	if actual := strings.Join(sut.AsSlice(), "|"); !strings.Contains(actual, "+rcon_password|hunter2") {
+		t.Errorf("Expected the rcon password to be included in %q.", actual)
+	}
+}
+
+func Test_ParseArgs_roundTrip(t *testing.T) {
+	validCases := []Args{
+		{GameMode: 1, Map: "de_inferno", TickRate: 128},
+		{
+			Args:             srcds.Args{Insecure: true, Bots: true, NoRestart: true},
+			GameMode:         2,
+			GameType:         0,
+			Hostname:         "Laclede's LAN Wingman #1",
+			TeamName1:        "Team Alpha",
+			TeamName2:        "Team Bravo",
+			LANMode:          1,
+			RConPassword:     "hunter2",
+			Map:              "workshop/123456/de_prime",
+			Password:         "secret",
+			TickRate:         64,
+			TVName:           "Laclede's LAN GOTV",
+			TVPassword:       "tv",
+			TVRelayPassword:  "relay",
+			UseRemoteConsole: true,
+		},
+	}
+
+	for _, test := range validCases {
+		if err := test.Validate(); err != nil {
+			t.Errorf("Arguments %+v should have been valid: %v", test, err)
+			continue
+		}
+
+		actual, unrecognized, err := ParseArgs(test.AsSlice())
+		if err != nil || len(unrecognized) > 0 {
+			t.Errorf("Arguments %q should have parsed without leftovers (%q): %v", test.AsSlice(), unrecognized, err)
+			continue
+		}
+
+		if actual != test {
+			t.Errorf("Expected arguments %+v to round trip not %+v.", test, actual)
+		}
+	}
+}
+
+func Test_ParseArgs(t *testing.T) {
+	argv := []string{"-game csgo", "-console", "-port", "27015", "+map", "de_dust2", "+hostname", "Laclede's", "LAN", "-tickrate", "128", "+sv_lan", "1"}
+
+	actual, unrecognized, err := ParseArgs(argv)
+	if err != nil {
+		t.Fatalf("Arguments %q should have parsed: %v", argv, err)
+	}
+
+	if actual.Map != "de_dust2" || actual.Hostname != "Laclede's LAN" || actual.LANMode != 1 || actual.TickRate != 128 || !actual.Bots {
+		t.Errorf("Unexpected arguments %+v.", actual)
+	}
+
+	if strings.Join(unrecognized, "|") != "-console|-port|27015" {
+		t.Errorf("Expected -console and -port to be unrecognized not %q.", unrecognized)
+	}
+
+	invalidCases := [][]string{{"-game", "tf"}, {"-tickrate", "fast"}, {"de_dust2"}}
+	for _, test := range invalidCases {
+		if _, _, err := ParseArgs(test); err == nil {
+			t.Errorf("Arguments %q should NOT have parsed.", test)
+		}
+	}
+}
+
+func Test_ParseArgs_defaults(t *testing.T) {
+	actual, _, err := ParseArgs(nil)
+	if err != nil {
+		t.Fatalf("No arguments should have parsed: %v", err)
+	}
+
+	if actual.Map != defaultMap || actual.TickRate != defaultTickRate {
+		t.Errorf("Expected map %q and tick rate %d not %+v.", defaultMap, defaultTickRate, actual)
+	}
+
+	// The option defaults can't reference the constants
+	args := reflect.TypeOf(Args{})
+	if f, _ := args.FieldByName("Map"); f.Tag.Get("default") != defaultMap {
+		t.Errorf("Expected the default map option to be %q not %q.", defaultMap, f.Tag.Get("default"))
+	}
+
+	if f, _ := args.FieldByName("TickRate"); f.Tag.Get("default") != strconv.Itoa(defaultTickRate) {
+		t.Errorf("Expected the default tick rate option to be %d not %q.", defaultTickRate, f.Tag.Get("default"))
+	}
+}
+
+func Test_Args_Validate(t *testing.T) {
+	valid := Args{GameMode: 1, Map: "de_inferno", TickRate: 128}
+
+	invalidCases := map[string]func(a *Args){
+		"tickrate":      func(a *Args) { a.TickRate = 100 },
+		"sv_lan":        func(a *Args) { a.LANMode = 2 },
+		"map":           func(a *Args) { a.Map = "de dust" },
+		"empty map":     func(a *Args) { a.Map = "" },
+		"hostname":      func(a *Args) { a.Hostname = "-insecure" },
+		"quoted":        func(a *Args) { a.TVName = `"GOTV"` },
+		"negative mode": func(a *Args) { a.GameMode = -1 },
+	}
+
+	for name, mutate := range invalidCases {
+		a := valid
+		mutate(&a)
+
+		if err := a.Validate(); err == nil {
+			t.Errorf("Arguments with an invalid %v should NOT have been valid.", name)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package csgo
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// backupListTimeout is how long to wait for the server to list its round backup files
+	backupListTimeout = 3 * time.Second
+	// backupListPollInterval is how often the listed round backup files are checked while waiting
+	backupListPollInterval = 50 * time.Millisecond
+)
+
+// RoundBackupRestored is emitted when a round backup is loaded, rolling the current match back in time
+type RoundBackupRestored struct {
+	srcds.EventTime
+	Match           int
+	Round           int // the round that will be replayed
+	File            string
+	RoundsDiscarded int
+}
+
+// processConsoleLine watches non-log console output for round backups being listed or restored; lines are applied once
+// every log entry output before them has been processed
+func (o *Observer) processConsoleLine(line srcds.ConsoleLine) {
+	o.mux.Lock()
+	if o.logEntriesProcessed >= line.LogEntriesBefore {
+		o.applyConsoleLine(line.Text)
+	} else {
+		o.pendingConsoleLines = append(o.pendingConsoleLines, line)
+	}
+	o.mux.Unlock()
+
+	o.game.events.Dispatch()
+}
+
+// applyPendingConsoleLines applies the queued console lines that were output before the next log entry; the caller must
+// hold the observer's lock
+func (o *Observer) applyPendingConsoleLines() {
+	for len(o.pendingConsoleLines) > 0 && o.pendingConsoleLines[0].LogEntriesBefore <= o.logEntriesProcessed {
+		o.applyConsoleLine(o.pendingConsoleLines[0].Text)
+		o.pendingConsoleLines = o.pendingConsoleLines[1:]
+	}
+}
+
+// applyConsoleLine updates the observed state from console output; the caller must hold the observer's lock
+func (o *Observer) applyConsoleLine(line string) {
+	if b, ok := parseBackupRestore(line); ok {
+		o.game.restoreRound(time.Now(), b)
+		return
+	}
+
+	if p, ok := parsePauseCommand(line); ok {
+		o.game.setPhaseModifier(time.Now(), paused, p)
+		return
+	}
+
+	if b, ok := parseBackupFile(line); ok {
+		if o.backupFiles == nil {
+			o.backupFiles = make(map[int]string)
+		}
+
+		o.backupFiles[b.rounds] = b.name
+	}
+}
+
+// restoreRound truncates the current match's round history to the rounds that had been completed when the backup was written
+func (g *gameInfo) restoreRound(at time.Time, b backupFile) {
+	if len(g.matches) == 0 {
+		return
+	}
+
+	matchIndex := len(g.matches) - 1
+	m := &g.matches[matchIndex]
+
+	if b.rounds > len(m.rounds) {
+		log.Warn().Int("match", matchIndex+1).Msgf("Backup %q is ahead of the %d observed rounds; relying on reported scores", b.name, len(m.rounds))
+		b.rounds = len(m.rounds)
+	}
+
+	if b.rounds == len(m.rounds) && !m.roundLive && m.ended.IsZero() {
+		// Already restored (e.g. the command was echoed after being sent)
+		return
+	}
+
+	discarded := len(m.rounds) - b.rounds
+	m.rounds = m.rounds[:b.rounds]
+	m.current = roundInfo{}
+	m.roundLive = false
+	m.ended = time.Time{}
+	m.decided, m.winner = false, ""
+
+	// Balances were restored along with the round
+	g.balances = nil
+	g.setPhase(at, freezePeriod)
+
+	log.Warn().Int("match", matchIndex+1).Int("round", b.rounds+1).Msgf("Round backup %q restored; discarded %d round(s)", b.name, discarded)
+
+	g.emit(RoundBackupRestored{
+		EventTime:       srcds.EventTime{At: at},
+		Match:           matchIndex + 1,
+		Round:           b.rounds + 1,
+		File:            b.name,
+		RoundsDiscarded: discarded,
+	})
+}
+
+// backupFile returns the name of the listed backup written before the specified round was played
+func (o *Observer) backupFile(round int) (string, bool) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	name, found := o.backupFiles[round-1]
+	return name, found
+}
+
+// RestoreRound lists the server's round backup files and loads the one written before the specified round was played
+func (s *Server) RestoreRound(round int) error {
+	if round < 1 {
+		return fmt.Errorf("Round %d is not a valid round to restore", round)
+	}
+
+	s.mux.Lock()
+	s.backupFiles = nil
+	s.mux.Unlock()
+
+	if !s.QueueCommand("mp_backup_restore_list_files") {
+		return errors.New("Round backup files couldn't be listed as SRCDS stopped")
+	}
+
+	for deadline := time.Now().Add(backupListTimeout); ; time.Sleep(backupListPollInterval) {
+		if name, found := s.backupFile(round); found {
+			if !s.QueueCommand("mp_backup_restore_load_file " + name) {
+				return fmt.Errorf("Round backup %q couldn't be loaded as SRCDS stopped", name)
+			}
+
+			// The server doesn't always echo the command; don't wait on it to roll back the round history
+			s.mux.Lock()
+			s.applyConsoleLine("mp_backup_restore_load_file " + name)
+			s.mux.Unlock()
+
+			s.game.events.Dispatch()
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("No round backup file was listed for round %d", round)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package csgo
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+)
+
+func Test_RoundBackupRestore(t *testing.T) {
+	restores := []RoundBackupRestored{}
+	discrepancies := 0
+
+	sut := NewObserver(1, 30, 6)
+	sut.OnEvent(func(e srcds.Event) {
+		switch v := e.(type) {
+		case RoundBackupRestored:
+			restores = append(restores, v)
+		case ScoreDiscrepancy:
+			discrepancies++
+		}
+	})
+
+	observeInto(t, sut,
+		`L 08/04/2019 - 20:00:00: World triggered "Match_Start" on "de_lltest"`,
+		`L 08/04/2019 - 20:00:10: World triggered "Round_Start"`,
+		`L 08/04/2019 - 20:00:31: Team "CT" triggered "SFUI_Notice_CTs_Win" (CT "1") (T "0")`,
+		`L 08/04/2019 - 20:01:10: World triggered "Round_Start"`,
+		`L 08/04/2019 - 20:01:31: Team "TERRORIST" triggered "SFUI_Notice_Terrorists_Win" (CT "1") (T "1")`,
+		`L 08/04/2019 - 20:02:10: World triggered "Round_Start"`,
+		`L 08/04/2019 - 20:02:31: Team "TERRORIST" triggered "SFUI_Notice_Terrorists_Win" (CT "1") (T "2")`,
+		// An admin lists the backups then rolls back to the start of round 2 from the console
+		`  backup_round00.txt  08/04 20:00  de_lltest  0:0`,
+		`  backup_round01.txt  08/04 20:01  de_lltest  1:0`,
+		`  backup_round02.txt  08/04 20:02  de_lltest  1:1`,
+		`mp_backup_restore_load_file backup_round01.txt`,
+		`L 08/04/2019 - 20:03:00: Team "CT" scored "1" with "1" players`,
+		`L 08/04/2019 - 20:03:00: Team "TERRORIST" scored "0" with "1" players`,
+		`L 08/04/2019 - 20:03:00: Starting Freeze period`,
+		`L 08/04/2019 - 20:03:10: World triggered "Round_Start"`,
+		`L 08/04/2019 - 20:03:31: Team "CT" triggered "SFUI_Notice_CTs_Win" (CT "2") (T "0")`,
+	)
+
+	if len(restores) != 1 {
+		t.Fatalf("Expected 1 restore to have been observed not %d.", len(restores))
+	}
+
+	if r := restores[0]; r.Match != 1 || r.Round != 2 || r.File != "backup_round01.txt" || r.RoundsDiscarded != 2 {
+		t.Errorf("Unexpected restore event %+v.", r)
+	}
+
+	if discrepancies != 0 {
+		t.Errorf("Expected the restored scores to agree with the server; not disagree %d times.", discrepancies)
+	}
+
+	if team1, team2 := sut.game.scoresCurrentMatch(); team1 != 2 || team2 != 0 {
+		t.Errorf("Expected a score of 2:0 not %d:%d.", team1, team2)
+	}
+
+	if name, found := sut.backupFile(3); !found || name != "backup_round02.txt" {
+		t.Errorf("Expected round 3's backup to be %q not %q.", "backup_round02.txt", name)
+	}
+
+	if _, found := sut.backupFile(4); found {
+		t.Error("Expected round 4 to not have a listed backup.")
+	}
+}
+
+func Test_RoundBackupRestore_rcon(t *testing.T) {
+	sut := observeLines(t,
+		`L 08/04/2019 - 20:00:00: World triggered "Match_Start" on "de_lltest"`,
+		`L 08/04/2019 - 20:00:10: World triggered "Round_Start"`,
+		`L 08/04/2019 - 20:00:31: Team "CT" triggered "SFUI_Notice_CTs_Win" (CT "1") (T "0")`,
+		`L 08/04/2019 - 20:01:10: World triggered "Round_Start"`,
+		`L 08/04/2019 - 20:01:31: Team "TERRORIST" triggered "SFUI_Notice_Terrorists_Win" (CT "1") (T "1")`,
+		`L 08/04/2019 - 20:01:40: rcon from "192.168.1.10:50123": command "mp_backup_restore_load_file backup_round00.txt"`,
+	)
+
+	if rounds := sut.game.currentMatchLastCompletedRound(); rounds != 0 {
+		t.Errorf("Expected round history to be truncated to 0 rounds not %d.", rounds)
+	}
+}
+
+func Test_Server_RestoreRound_stopped(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Requires a POSIX shell")
+	}
+
+	sut := NewServer()
+	if err := sut.SetExecContext(context.Background(), "sh", "-c", "echo stopped"); err != nil {
+		t.Fatalf("Unexpected error setting the exec: %v", err)
+	}
+
+	if err := sut.Read(); err != nil {
+		t.Fatalf("Unexpected error starting the server: %v", err)
+	}
+	sut.Wait()
+
+	done := make(chan error)
+	go func() { done <- sut.RestoreRound(2) }()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("Expected an error restoring a round once the server stopped.")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Restoring a round blocked after the server stopped.")
+	}
+}
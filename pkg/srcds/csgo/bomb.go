@@ -0,0 +1,123 @@
+package csgo
+
+import (
+	"time"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+)
+
+const triggerTargetBombed = "SFUI_Notice_Target_Bombed"
+
+// bombEvent contains information about a client's interaction with the bomb
+type bombEvent struct {
+	action bombAction
+	at     time.Time
+	client srcds.Client
+	site   string
+}
+
+// bombInfo contains the bomb objective history for a round
+type bombInfo struct {
+	defusedAt     time.Time
+	defuser       srcds.Client
+	defuserHadKit bool
+	events        []bombEvent
+	plantedAt     time.Time
+	planter       srcds.Client
+	site          string
+}
+
+// record a client's interaction with the bomb
+func (b *bombInfo) record(at time.Time, c srcds.Client, m clientBombEvent) {
+	b.events = append(b.events, bombEvent{action: m.action, at: at, client: c, site: m.site})
+
+	switch m.action {
+	case bombBeginPlant:
+		if len(m.site) > 0 {
+			b.site = m.site
+		}
+	case bombPlanted:
+		b.plantedAt = at
+		b.planter = c
+
+		if len(m.site) > 0 {
+			b.site = m.site
+		}
+	case bombBeginDefuseKit, bombBeginDefuse:
+		b.defuserHadKit = m.action == bombBeginDefuseKit
+	case bombDefused:
+		b.defusedAt = at
+		b.defuser = c
+
+		// Only trust the kit status when it was the defuser who began defusing last
+		for i := len(b.events) - 2; i >= 0; i-- {
+			if e := b.events[i]; e.action == bombBeginDefuseKit || e.action == bombBeginDefuse {
+				b.defuserHadKit = srcds.ClientsAreEquivalent(e.client, c) && e.action == bombBeginDefuseKit
+				break
+			}
+		}
+	}
+}
+
+// recordBombEvent attaches a client's interaction with the bomb to the active round
+func (g *gameInfo) recordBombEvent(at time.Time, c srcds.Client, m clientBombEvent) {
+	if r := g.activeRound(); r != nil {
+		r.bomb.record(at, c, m)
+	}
+}
+
+// BombEvent is a single interaction a player had with the bomb
+type BombEvent struct {
+	Action   string
+	At       time.Time
+	SteamID  string
+	Username string
+	Site     string
+}
+
+// BombSummary describes what happened with the bomb during a round
+type BombSummary struct {
+	Planted        bool
+	PlantedAt      time.Time
+	PlantedBy      string // SteamID
+	Site           string // empty when the server did not log the bombsite
+	Defused        bool
+	DefusedAt      time.Time
+	DefusedBy      string // SteamID
+	DefusedWithKit bool
+	Exploded       bool
+	Events         []BombEvent
+}
+
+func (b *bombInfo) snapshot(winningTrigger string) BombSummary {
+	r := BombSummary{
+		Planted:   !b.plantedAt.IsZero(),
+		PlantedAt: b.plantedAt,
+		Site:      b.site,
+		Defused:   !b.defusedAt.IsZero(),
+		DefusedAt: b.defusedAt,
+		Exploded:  winningTrigger == triggerTargetBombed,
+		Events:    make([]BombEvent, 0, len(b.events)),
+	}
+
+	if r.Planted {
+		r.PlantedBy = statsKey(b.planter)
+	}
+
+	if r.Defused {
+		r.DefusedBy = statsKey(b.defuser)
+		r.DefusedWithKit = b.defuserHadKit
+	}
+
+	for _, e := range b.events {
+		r.Events = append(r.Events, BombEvent{
+			Action:   string(e.action),
+			At:       e.at,
+			SteamID:  statsKey(e.client),
+			Username: e.client.Username,
+			Site:     e.site,
+		})
+	}
+
+	return r
+}
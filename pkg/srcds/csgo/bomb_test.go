@@ -0,0 +1,50 @@
+package csgo
+
+import (
+	"testing"
+)
+
+func Test_BombSummary(t *testing.T) {
+	sut := observeLines(t,
+		`L 08/04/2019 - 20:00:00: World triggered "Match_Start" on "de_lltest"`,
+		// Round 1; planted then defused with a kit after a defuser without a kit died
+		`L 08/04/2019 - 20:00:10: World triggered "Round_Start"`,
+		`L 08/04/2019 - 20:00:11: `+statsXray+` triggered "Got_The_Bomb"`,
+		`L 08/04/2019 - 20:00:40: `+statsXray+` triggered "Planted_The_Bomb" at bombsite A`,
+		`L 08/04/2019 - 20:00:45: `+statsBravo+` triggered "Begin_Bomb_Defuse_Without_Kit"`,
+		`L 08/04/2019 - 20:00:46: `+statsAlpha+` triggered "Begin_Bomb_Defuse_With_Kit"`,
+		`L 08/04/2019 - 20:00:51: `+statsAlpha+` triggered "Defused_The_Bomb"`,
+		`L 08/04/2019 - 20:00:51: Team "CT" triggered "SFUI_Notice_Bomb_Defused" (CT "1") (T "0")`,
+		// Round 2; bomb explodes
+		`L 08/04/2019 - 20:01:10: World triggered "Round_Start"`,
+		`L 08/04/2019 - 20:01:40: `+statsYanke+` triggered "Planted_The_Bomb"`,
+		`L 08/04/2019 - 20:02:20: Team "TERRORIST" triggered "SFUI_Notice_Target_Bombed" (CT "1") (T "1")`,
+	)
+
+	match, ok := sut.CurrentMatch()
+	if !ok || len(match.Rounds) != 2 {
+		t.Fatalf("Expected a current match with 2 rounds but got %+v.", match)
+	}
+
+	first := match.Rounds[0].Bomb
+	if !first.Planted || first.PlantedBy != "STEAM_1:0:2001" || first.Site != "A" || first.PlantedAt.Second() != 40 {
+		t.Errorf("Round 1 plant was not recorded correctly: %+v", first)
+	}
+
+	if !first.Defused || first.DefusedBy != "STEAM_1:0:1001" || !first.DefusedWithKit || first.DefusedAt.Second() != 51 {
+		t.Errorf("Round 1 defuse was not recorded correctly: %+v", first)
+	}
+
+	if first.Exploded {
+		t.Error("Round 1 bomb should not have exploded.")
+	}
+
+	if len(first.Events) != 5 {
+		t.Errorf("Expected 5 bomb events in round 1 not %d.", len(first.Events))
+	}
+
+	second := match.Rounds[1].Bomb
+	if !second.Planted || second.PlantedBy != "STEAM_1:0:2002" || second.Site != "" || second.Defused || !second.Exploded {
+		t.Errorf("Round 2 bomb was not recorded correctly: %+v", second)
+	}
+}
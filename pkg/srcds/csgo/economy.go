@@ -0,0 +1,233 @@
+package csgo
+
+import (
+	"strings"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+)
+
+// buyType classifies how much a team invested into a round
+type buyType string
+
+const (
+	buyUnknown buyType = ""
+	buyPistol  buyType = "pistol"
+	buyEco     buyType = "eco"
+	buyForce   buyType = "force"
+	buyFull    buyType = "full"
+)
+
+const (
+	// ecoMaxAverageValue is the highest average equipment value per player still considered an eco
+	ecoMaxAverageValue int = 1500
+	// fullBuyMinAverageValue is the lowest average equipment value per player considered a full buy
+	fullBuyMinAverageValue int = 3500
+)
+
+// teamEconomy contains a team's economic summary for a round
+type teamEconomy struct {
+	buy            buyType
+	equipmentValue int
+	moneySpent     int
+}
+
+// classifyBuy determines the buy type from a team's average equipment value
+func classifyBuy(equipmentValue, players int, pistolRound bool) buyType {
+	if pistolRound {
+		return buyPistol
+	}
+
+	if players < 1 {
+		return buyUnknown
+	}
+
+	switch average := equipmentValue / players; {
+	case average <= ecoMaxAverageValue:
+		return buyEco
+	case average < fullBuyMinAverageValue:
+		return buyForce
+	default:
+		return buyFull
+	}
+}
+
+// equipmentValue returns the purchase price of an item; unknown items (knives, c4, etc) have no value
+func equipmentValue(item string) int {
+	item = strings.ToLower(item)
+	item = strings.TrimPrefix(item, "weapon_")
+	item = strings.TrimPrefix(item, "item_")
+
+	if i := strings.Index(item, "("); i > 0 {
+		item = item[:i] // kevlar(100)
+	}
+
+	switch item {
+	case "decoy":
+		return 50
+	case "glock", "hkp2000", "usp_silencer", "flashbang", "taser", "helmet":
+		return 200
+	case "p250", "hegrenade", "smokegrenade":
+		return 300
+	case "elite", "molotov", "defuser":
+		return 400
+	case "fiveseven", "tec9", "cz75a":
+		return 500
+	case "revolver", "incgrenade":
+		return 600
+	case "kevlar":
+		return 650
+	case "deagle":
+		return 700
+	case "assaultsuit", "vesthelm":
+		return 1000
+	case "mac10", "nova":
+		return 1050
+	case "sawedoff":
+		return 1100
+	case "ump45":
+		return 1200
+	case "mp9", "mag7":
+		return 1300
+	case "bizon":
+		return 1400
+	case "mp7", "mp5sd":
+		return 1500
+	case "ssg08", "negev":
+		return 1700
+	case "galilar":
+		return 1800
+	case "xm1014":
+		return 2000
+	case "famas":
+		return 2050
+	case "p90":
+		return 2350
+	case "ak47":
+		return 2700
+	case "m4a1_silencer":
+		return 2900
+	case "sg556":
+		return 3000
+	case "m4a1":
+		return 3100
+	case "aug":
+		return 3300
+	case "awp":
+		return 4750
+	case "g3sg1", "scar20":
+		return 5000
+	case "m249":
+		return 5200
+	}
+
+	return 0
+}
+
+// classifyEconomy determines each team's buy type for the active round
+func (g *gameInfo) classifyEconomy(pistolRound bool) {
+	r := g.activeRound()
+	if r == nil {
+		return
+	}
+
+	players := make(map[team]int)
+	r.economy = make(map[team]teamEconomy)
+
+	for _, s := range r.players {
+		t := team(s.Team)
+		if t != mpTeam1 && t != mpTeam2 {
+			continue
+		}
+
+		e := r.economy[t]
+		e.equipmentValue += s.EquipmentValue
+		e.moneySpent += s.MoneySpent
+		r.economy[t] = e
+		players[t]++
+	}
+
+	for t, e := range r.economy {
+		e.buy = classifyBuy(e.equipmentValue, players[t], pistolRound)
+		r.economy[t] = e
+	}
+}
+
+// recordEquipment records the equipment a client carried out of the buyzone
+func (g *gameInfo) recordEquipment(c srcds.Client, equipment []string) {
+	r := g.activeRound()
+	if r == nil || !trackable(c) {
+		return
+	}
+
+	s := r.playerStats(c)
+	s.Equipment = equipment
+	s.EquipmentValue = 0
+
+	for _, item := range equipment {
+		s.EquipmentValue += equipmentValue(item)
+	}
+}
+
+// recordMoneyChange records a change in a client's balance
+func (g *gameInfo) recordMoneyChange(c srcds.Client, m clientMoneyChange) {
+	if !trackable(c) {
+		return
+	}
+
+	if g.balances == nil {
+		g.balances = make(map[string]int)
+	}
+
+	g.balances[statsKey(c)] = m.balance
+
+	r := g.activeRound()
+	if r == nil {
+		return
+	}
+
+	s := r.playerStats(c)
+	if !s.moneyKnown {
+		s.MoneyStart = m.previous
+		s.moneyKnown = true
+	}
+
+	if len(m.purchase) > 0 {
+		// Refunds are logged as positive purchases
+		s.MoneySpent -= m.delta
+	}
+}
+
+// recordPurchase records an item purchased by a client
+func (g *gameInfo) recordPurchase(c srcds.Client, item string) {
+	r := g.activeRound()
+	if r == nil || !trackable(c) {
+		return
+	}
+
+	s := r.playerStats(c)
+	s.Purchases = append(s.Purchases, item)
+
+	if len(s.Equipment) == 0 {
+		// Until the client leaves the buyzone the best estimate is what they bought
+		s.EquipmentValue += equipmentValue(item)
+	}
+}
+
+// seedMoney sets the starting balance of a client for the active round from their last known balance
+func (g *gameInfo) seedMoney(s *PlayerRoundStats, c srcds.Client) {
+	if s.moneyKnown {
+		return
+	}
+
+	if balance, found := g.balances[statsKey(c)]; found {
+		s.MoneyStart = balance
+		s.moneyKnown = true
+	}
+}
+
+// TeamEconomy is a team's economic summary for a round
+type TeamEconomy struct {
+	Buy            string // pistol, eco, force, or full
+	EquipmentValue int
+	MoneySpent     int
+}
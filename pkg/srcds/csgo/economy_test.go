@@ -0,0 +1,111 @@
+package csgo
+
+import (
+	"testing"
+)
+
+func Test_classifyBuy(t *testing.T) {
+	tests := []struct {
+		equipmentValue int
+		players        int
+		pistolRound    bool
+		expected       buyType
+	}{
+		{4000, 5, true, buyPistol},
+		{0, 0, false, buyUnknown},
+		{1000, 5, false, buyEco},
+		{7500, 5, false, buyEco},
+		{12000, 5, false, buyForce},
+		{17500, 5, false, buyFull},
+		{25000, 5, false, buyFull},
+		{3600, 1, false, buyFull},
+	}
+
+	for _, test := range tests {
+		if actual := classifyBuy(test.equipmentValue, test.players, test.pistolRound); actual != test.expected {
+			t.Errorf("Equipment value %d for %d players (pistol round %t) should be classified as %q not %q.",
+				test.equipmentValue, test.players, test.pistolRound, test.expected, actual)
+		}
+	}
+}
+
+func Test_equipmentValue(t *testing.T) {
+	tests := map[string]int{
+		"weapon_ak47":      2700,
+		"m4a1_silencer":    2900,
+		"item_assaultsuit": 1000,
+		"kevlar(100)":      650,
+		"helmet":           200,
+		"weapon_knife_t":   0,
+		"weapon_c4":        0,
+		"WEAPON_AWP":       4750,
+	}
+
+	for item, expected := range tests {
+		if actual := equipmentValue(item); actual != expected {
+			t.Errorf("Expected %q to be worth %d not %d.", item, expected, actual)
+		}
+	}
+}
+
+func Test_Economy(t *testing.T) {
+	sut := observeLines(t,
+		`L 08/04/2019 - 20:00:00: World triggered "Match_Start" on "de_lltest"`,
+		`L 08/04/2019 - 20:00:01: "Alpha<3><STEAM_1:0:1001>" switched from team <Unassigned> to <CT>`,
+		`L 08/04/2019 - 20:00:01: "Xray<5><STEAM_1:0:2001>" switched from team <Unassigned> to <TERRORIST>`,
+		// Round 1 (pistol)
+		`L 08/04/2019 - 20:00:02: Starting Freeze period`,
+		`L 08/04/2019 - 20:00:03: `+statsAlpha+` money change 800-650 = $150 (tracked) (purchase: item_kevlar)`,
+		`L 08/04/2019 - 20:00:03: `+statsAlpha+` purchased "item_kevlar"`,
+		`L 08/04/2019 - 20:00:10: World triggered "Round_Start"`,
+		`L 08/04/2019 - 20:00:12: `+statsAlpha+` left buyzone with [ weapon_knife weapon_hkp2000 kevlar(100) ]`,
+		`L 08/04/2019 - 20:00:12: `+statsXray+` left buyzone with [ weapon_knife_t weapon_glock ]`,
+		`L 08/04/2019 - 20:00:30: Team "CT" triggered "SFUI_Notice_CTs_Win" (CT "1") (T "0")`,
+		`L 08/04/2019 - 20:00:30: `+statsAlpha+` money change 150+3250 = $3400 (tracked)`,
+		`L 08/04/2019 - 20:00:30: `+statsXray+` money change 800+1400 = $2200 (tracked)`,
+		// Round 2; CT full buys and T saves
+		`L 08/04/2019 - 20:00:35: Starting Freeze period`,
+		`L 08/04/2019 - 20:00:36: `+statsAlpha+` money change 3400-1000 = $2400 (tracked) (purchase: item_assaultsuit)`,
+		`L 08/04/2019 - 20:00:36: `+statsAlpha+` purchased "item_assaultsuit"`,
+		`L 08/04/2019 - 20:00:36: `+statsAlpha+` money change 2400-2050 = $350 (tracked) (purchase: weapon_famas)`,
+		`L 08/04/2019 - 20:00:36: `+statsAlpha+` purchased "famas"`,
+		`L 08/04/2019 - 20:00:37: `+statsAlpha+` money change 350+2050 = $2400 (tracked) (purchase: weapon_famas)`,
+		`L 08/04/2019 - 20:00:38: `+statsAlpha+` money change 2400-2050 = $350 (tracked) (purchase: weapon_famas)`,
+		`L 08/04/2019 - 20:00:38: `+statsAlpha+` money change 350-200 = $150 (tracked) (purchase: weapon_flashbang)`,
+		`L 08/04/2019 - 20:00:38: `+statsAlpha+` purchased "flashbang"`,
+		`L 08/04/2019 - 20:00:45: World triggered "Round_Start"`,
+		`L 08/04/2019 - 20:00:47: `+statsAlpha+` left buyzone with [ weapon_knife weapon_hkp2000 weapon_famas weapon_flashbang kevlar(100) helmet ]`,
+		`L 08/04/2019 - 20:01:30: Team "CT" triggered "SFUI_Notice_CTs_Win" (CT "2") (T "0")`,
+	)
+
+	match, ok := sut.CurrentMatch()
+	if !ok || len(match.Rounds) != 2 {
+		t.Fatalf("Expected a current match with 2 rounds but got %+v.", match)
+	}
+
+	first := match.Rounds[0]
+	if first.Economy[string(mpTeam1)].Buy != string(buyPistol) || first.Economy[string(mpTeam2)].Buy != string(buyPistol) {
+		t.Errorf("Round 1 should have been a pistol round for both teams: %+v", first.Economy)
+	}
+
+	if alpha := first.Players["STEAM_1:0:1001"]; alpha.MoneyStart != 800 || alpha.MoneySpent != 650 || alpha.EquipmentValue != 850 || len(alpha.Purchases) != 1 {
+		t.Errorf("Round 1 economy for Alpha was not recorded correctly: %+v", alpha)
+	}
+
+	second := match.Rounds[1]
+	if ct := second.Economy[string(mpTeam1)]; ct.Buy != string(buyForce) || ct.MoneySpent != 3250 || ct.EquipmentValue != 3300 {
+		t.Errorf("Round 2 economy for mp_team1 was not recorded correctly: %+v", ct)
+	}
+
+	if tr := second.Economy[string(mpTeam2)]; tr.Buy != string(buyEco) || tr.MoneySpent != 0 {
+		t.Errorf("Round 2 economy for mp_team2 was not recorded correctly: %+v", tr)
+	}
+
+	if xray := second.Players["STEAM_1:0:2001"]; xray.MoneyStart != 2200 {
+		t.Errorf("Xray's round 2 starting money should have been seeded from their last balance; got %+v", xray)
+	}
+
+	if alpha := match.Players["STEAM_1:0:1001"]; alpha.MoneySpent != 3900 {
+		t.Errorf("Alpha should have spent 3900 across the match not %d.", alpha.MoneySpent)
+	}
+}
@@ -3,6 +3,7 @@ package csgo
 import (
 	"time"
 
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
 	"github.com/rs/zerolog/log"
 )
 
@@ -20,21 +21,28 @@ type lastInt int
 
 // matchInfo contains statistics about a match
 type matchInfo struct {
-	ended   time.Time
-	mapName string
-	rounds  []roundInfo
-	started time.Time
+	current   roundInfo
+	decided   bool
+	ended     time.Time
+	mapName   string
+	roundLive bool
+	rounds    []roundInfo
+	started   time.Time
+	winner    team
 }
 
-type matchPhase uint16
-
-const (
-	unknown matchPhase = 1 << iota
-	freezePeriod
-)
-
 // roundInfo contains statistics about a round
 type roundInfo struct {
+	alive              map[string]team
+	bomb               bombInfo
+	clutches           []clutchInfo
+	economy            map[team]teamEconomy
+	health             map[string]int
+	kills              []killInfo
+	players            map[string]*PlayerRoundStats
+	reconstructed      bool
+	started            time.Time
+	stats              *srcds.RoundStats
 	winningAffiliation affiliation
 	winningTeam        team
 	winningTrigger     string
@@ -51,15 +59,29 @@ const (
 
 // reset the match information; preserving the map name
 func (m *matchInfo) reset(start time.Time) {
+	m.decided = false
 	m.ended = time.Time{}
 	m.started = start
+	m.current = roundInfo{}
+	m.roundLive = false
 	m.rounds = []roundInfo{}
+	m.winner = ""
 }
 
 type gameInfo struct {
+	balances    map[string]int
+	events      srcds.EventQueue
 	matches     []matchInfo
 	mpTeamname1 string
 	mpTeamname2 string
+	phase       matchPhase
+	phaseSince  time.Time
+	playerNames map[string]string
+	// knifeRoundOver is set from the end of a knife round until the next round starts; scores the server reports for
+	// the knife round are ignored
+	knifeRoundOver bool
+	// warmupMatchSeen is set once the match start beginning warmup has been observed
+	warmupMatchSeen bool
 }
 
 func (g *gameInfo) currentMatchLastCompletedRound() lastInt {
@@ -136,11 +158,14 @@ func (g *gameInfo) setRoundWinner(aff affiliation, t team, trigger string) {
 		g.matches[matchIndex].rounds = []roundInfo{}
 	}
 
-	g.matches[matchIndex].rounds = append(g.matches[matchIndex].rounds, roundInfo{
-		winningAffiliation: aff,
-		winningTeam:        t,
-		winningTrigger:     trigger,
-	})
+	round := g.matches[matchIndex].current
+	round.winningAffiliation = aff
+	round.winningTeam = t
+	round.winningTrigger = trigger
+
+	g.matches[matchIndex].rounds = append(g.matches[matchIndex].rounds, round)
+	g.matches[matchIndex].current = roundInfo{}
+	g.matches[matchIndex].roundLive = false
 
 	lastRound := int(g.currentMatchLastCompletedRound())
 	mpTeam1Wins, mpTeam2Wins := g.scoresCurrentMatch()
@@ -148,9 +173,51 @@ func (g *gameInfo) setRoundWinner(aff affiliation, t team, trigger string) {
 	log.Info().Int("match", matchIndex+1).Int("round", lastRound).Int("team1_score", int(mpTeam1Wins)).Int("team2_score", int(mpTeam2Wins)).Msgf("Round %02d won by %v (%v as %v)", lastRound, t, g.teamName(t), aff)
 }
 
+// prepareRound begins tracking a new round for the current match as soon as its freeze period starts
+func (g *gameInfo) prepareRound() {
+	if len(g.matches) == 0 {
+		return
+	}
+
+	matchIndex := len(g.matches) - 1
+	g.matches[matchIndex].current = roundInfo{}
+	g.matches[matchIndex].roundLive = true
+}
+
+// discardKnifeRound drops the knife round that just ended without recording it as one of the match's rounds
+func (g *gameInfo) discardKnifeRound() {
+	g.knifeRoundOver = true
+
+	if len(g.matches) == 0 {
+		return
+	}
+
+	matchIndex := len(g.matches) - 1
+	g.matches[matchIndex].current = roundInfo{}
+	g.matches[matchIndex].roundLive = false
+}
+
+// startRound marks the start of live play for the current round; preparing the round if its freeze period was missed
+func (g *gameInfo) startRound(at time.Time) {
+	if len(g.matches) == 0 {
+		return
+	}
+
+	g.knifeRoundOver = false
+
+	matchIndex := len(g.matches) - 1
+	if !g.matches[matchIndex].roundLive {
+		g.prepareRound()
+	}
+
+	g.matches[matchIndex].current.started = at
+}
+
 // nextMatch will end the current match and start the next; if the current match has one or fewer completed round it will be reset and reused
 // TODO - better  documentation!
 func (g *gameInfo) nextMatch(mapName string, start time.Time) {
+	g.knifeRoundOver = false
+
 	if len(g.matches) == 0 {
 		g.matches = append(g.matches, matchInfo{
 			mapName: mapName,
@@ -179,3 +246,23 @@ func (g *gameInfo) nextMatch(mapName string, start time.Time) {
 	// Use to reset stats; even if no round history details are being reset
 	g.matches[i].reset(start)
 }
+
+// attachRoundStats attaches the statistics reported by the server to the round they describe; stats reported before the
+// round was recorded are attached to the round being played
+func (g *gameInfo) attachRoundStats(stats srcds.RoundStats) {
+	if len(g.matches) == 0 {
+		return
+	}
+
+	m := &g.matches[len(g.matches)-1]
+
+	i, ok := stats.RoundIndex(len(m.rounds))
+	switch {
+	case !ok:
+		log.Debug().Int("round", stats.Round).Msg("Ignoring round stats for a round that isn't part of the current match")
+	case i < len(m.rounds):
+		m.rounds[i].stats = &stats
+	default:
+		m.current.stats = &stats
+	}
+}
@@ -3,6 +3,8 @@ package csgo
 import (
 	"testing"
 	"time"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
 )
 
 func Test_lastCompletedRound(t *testing.T) {
@@ -88,3 +90,46 @@ func Test_gameInfo_nextMatch(t *testing.T) {
 	//create new entry doesn't crash
 	//doesn't advance when zero rounds
 }
+
+func Test_attachRoundStats(t *testing.T) {
+	g := &gameInfo{}
+	g.nextMatch("de_lltest", time.Now())
+	g.setRoundWinner(counterterrorist, mpTeam1, "SFUI_Notice_CTs_Win")
+	g.setRoundWinner(terrorist, mpTeam2, "SFUI_Notice_Terrorists_Win")
+
+	g.attachRoundStats(srcds.RoundStats{Round: 2, Map: "de_lltest"})
+	g.attachRoundStats(srcds.RoundStats{Round: 3, Map: "de_lltest"})
+
+	m := g.matches[0]
+	if m.rounds[0].stats != nil || m.rounds[1].stats == nil || m.rounds[1].stats.Round != 2 {
+		t.Errorf("Expected stats for round 2 to be attached to the second round not %+v.", m.rounds)
+	}
+
+	if m.current.stats == nil || m.current.stats.Round != 3 {
+		t.Errorf("Expected stats for round 3 to be attached to the round being played not %+v.", m.current.stats)
+	}
+}
+
+func Test_roundInfo_snapshot_stats(t *testing.T) {
+	r := roundInfo{stats: &srcds.RoundStats{
+		Round:   1,
+		Players: []srcds.PlayerRoundStats{{SteamID: "U:1:1001", Kills: 2, Fields: map[string]string{"kills": "2"}}},
+	}}
+
+	s := r.snapshot(1)
+	if s.Stats == nil || s.Stats == r.stats {
+		t.Fatalf("Expected the snapshot to have its own copy of the round stats not %p.", s.Stats)
+	}
+
+	s.Stats.Round = 5
+	s.Stats.Players[0].Kills = 5
+	s.Stats.Players[0].Fields["kills"] = "5"
+
+	if p := r.stats.Players[0]; r.stats.Round != 1 || p.Kills != 2 || p.Fields["kills"] != "2" {
+		t.Errorf("Expected the observed round stats to be unchanged by the snapshot not %+v.", r.stats)
+	}
+
+	if s := (&roundInfo{}).snapshot(1); s.Stats != nil {
+		t.Errorf("Expected no round stats when none were reported not %+v.", s.Stats)
+	}
+}
@@ -0,0 +1,122 @@
+package csgo
+
+import (
+	"strconv"
+)
+
+const (
+	defaultGameMode int = 1
+	defaultGameType int = 0
+)
+
+// gameMode is a rule profile for one of the ways CSGO can be played, as selected by the game_type and game_mode cvars
+type gameMode struct {
+	name         string
+	gameType     int
+	gameMode     int
+	roundBased   bool           // false if the match is won by time or frag limits instead of by winning rounds
+	defaults     map[string]int // rule cvars that differ between game modes
+	aceKills     int            // kills in a single round that wipe out a full opposing team
+	minMultiKill int            // fewest kills in a single round that is considered a highlight
+}
+
+// lookupGameMode returns the rule profile for the specified game_type and game_mode; competitive rules are assumed for
+// unrecognized combinations
+func lookupGameMode(gameType, mode int) (gameMode, bool) {
+	m := gameMode{gameType: gameType, gameMode: mode, roundBased: true, aceKills: 5, minMultiKill: 3}
+
+	switch {
+	case gameType == 0 && mode == 0:
+		m.name, m.aceKills = "casual", 10
+		m.defaults = map[string]int{"mp_halftime": 0, "mp_maxrounds": 15, "mp_match_can_clinch": 1, "mp_overtime_enable": 0}
+	case gameType == defaultGameType && mode == defaultGameMode:
+		return competitiveGameMode(), true
+	case gameType == 0 && mode == 2:
+		m.name, m.aceKills, m.minMultiKill = "wingman", 2, 2
+		m.defaults = map[string]int{"mp_halftime": 1, "mp_maxrounds": 16, "mp_match_can_clinch": 1, "mp_overtime_enable": 0}
+	case gameType == 1 && mode == 0:
+		m.name, m.roundBased = "arms_race", false
+		m.defaults = map[string]int{"mp_halftime": 0, "mp_maxrounds": 1, "mp_match_can_clinch": 0, "mp_overtime_enable": 0}
+	case gameType == 1 && mode == 1:
+		m.name = "demolition"
+		m.defaults = map[string]int{"mp_halftime": 1, "mp_maxrounds": 20, "mp_match_can_clinch": 1, "mp_overtime_enable": 0}
+	case gameType == 1 && mode == 2:
+		m.name, m.roundBased = "deathmatch", false
+		m.defaults = map[string]int{"mp_halftime": 0, "mp_maxrounds": 1, "mp_match_can_clinch": 0, "mp_overtime_enable": 0}
+	case gameType == 3 && mode == 0:
+		// Retakes plugins run on the custom game type; rounds are played until the map changes and the plugin manages sides
+		m.name = "retakes"
+		m.defaults = map[string]int{"mp_halftime": 0, "mp_maxrounds": 999, "mp_match_can_clinch": 0, "mp_overtime_enable": 0}
+	default:
+		return competitiveGameMode(), false
+	}
+
+	return m, true
+}
+
+// competitiveGameMode returns the rule profile of competitive matches; these rules are the defaults of every other mode
+func competitiveGameMode() gameMode {
+	return gameMode{
+		name:       "competitive",
+		gameType:   defaultGameType,
+		gameMode:   defaultGameMode,
+		roundBased: true,
+		defaults: map[string]int{
+			"mp_halftime": defaultMpHalftime, "mp_maxrounds": defaultMpMaxrounds, "mp_match_can_clinch": defaultMpMatchCanClinch,
+			"mp_overtime_enable": defaultMpOvertimeEnabled, "mp_overtime_maxrounds": defaultMpOvertimeMaxrounds,
+		},
+		aceKills:     5,
+		minMultiKill: 3,
+	}
+}
+
+// defaultFor returns the game mode's default value for a rule cvar, falling back to the competitive default
+func (m gameMode) defaultFor(name string) int {
+	if v, found := m.defaults[name]; found {
+		return v
+	}
+
+	if v, found := competitiveGameMode().defaults[name]; found {
+		return v
+	}
+
+	if name == "mp_do_warmup" {
+		return defaultMpDoWarmupPeriod
+	}
+
+	return 0
+}
+
+// NewGameModeObserver creates an observer whose rules default to those of the specified game_type and game_mode;
+// rule cvars reported by the server still take precedence
+func NewGameModeObserver(gameType, mode int) *Observer {
+	o := newObserver()
+
+	o.srcdsObserver.AddCvarWatcherDefault("game_type", strconv.Itoa(gameType))
+	o.srcdsObserver.AddCvarWatcherDefault("game_mode", strconv.Itoa(mode))
+
+	return o
+}
+
+// GameMode returns the name of the game mode whose rules are being applied (e.g. "competitive" or "wingman")
+func (o *Observer) GameMode() string {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	return o.gameMode().name
+}
+
+// gameMode returns the rule profile selected by the game_type and game_mode cvars
+func (o *Observer) gameMode() gameMode {
+	gameType, _ := o.srcdsObserver.TryCvarAsInt("game_type", defaultGameType)
+	mode, _ := o.srcdsObserver.TryCvarAsInt("game_mode", defaultGameMode)
+
+	m, _ := lookupGameMode(gameType, mode)
+	return m
+}
+
+// rule returns the value of a rule cvar, falling back to the current game mode's default
+func (o *Observer) rule(name string) int {
+	v, _ := o.srcdsObserver.TryCvarAsInt(name, o.gameMode().defaultFor(name))
+	return v
+}
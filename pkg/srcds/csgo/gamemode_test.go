@@ -0,0 +1,159 @@
+package csgo
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+)
+
+func Test_lookupGameMode(t *testing.T) {
+	validCases := map[string]struct {
+		gameType int
+		gameMode int
+	}{
+		"casual":      {0, 0},
+		"competitive": {0, 1},
+		"wingman":     {0, 2},
+		"arms_race":   {1, 0},
+		"demolition":  {1, 1},
+		"deathmatch":  {1, 2},
+		"retakes":     {3, 0},
+	}
+
+	for expected, test := range validCases {
+		test := test
+		t.Run(expected, func(t *testing.T) {
+			if actual, found := lookupGameMode(test.gameType, test.gameMode); !found || actual.name != expected {
+				t.Errorf("Expected game_type %d and game_mode %d to be %q not %q.", test.gameType, test.gameMode, expected, actual.name)
+			}
+		})
+	}
+
+	invalidCases := []struct {
+		gameType int
+		gameMode int
+	}{{0, 9}, {2, 0}, {-1, -1}}
+
+	for _, test := range invalidCases {
+		if actual, found := lookupGameMode(test.gameType, test.gameMode); found || actual.name != "competitive" {
+			t.Errorf("Expected game_type %d and game_mode %d to fall back to competitive rules not %q.", test.gameType, test.gameMode, actual.name)
+		}
+	}
+}
+
+func Test_gameMode_defaultFor(t *testing.T) {
+	wingman, _ := lookupGameMode(0, 2)
+
+	if actual := wingman.defaultFor("mp_maxrounds"); actual != 16 {
+		t.Errorf("Expected wingman to default to 16 rounds not %d.", actual)
+	}
+
+	if actual := wingman.defaultFor("mp_do_warmup"); actual != defaultMpDoWarmupPeriod {
+		t.Errorf("Expected wingman to fall back to the default `mp_do_warmup` not %d.", actual)
+	}
+}
+
+func Test_NewObserver_roundLimitDefaults(t *testing.T) {
+	sut := NewObserver(1, 0, 0)
+	if mpMaxrounds, mpOvertimeMaxrounds := sut.rule("mp_maxrounds"), sut.rule("mp_overtime_maxrounds"); mpMaxrounds != defaultMpMaxrounds || mpOvertimeMaxrounds != defaultMpOvertimeMaxrounds {
+		t.Errorf("Expected unset round limits to default to %d and %d not %d and %d.", defaultMpMaxrounds, defaultMpOvertimeMaxrounds, mpMaxrounds, mpOvertimeMaxrounds)
+	}
+
+	observeInto(t, sut,
+		`L 08/04/2019 - 20:00:00: server_cvar: "game_type" "0"`,
+		`L 08/04/2019 - 20:00:00: server_cvar: "game_mode" "2"`,
+	)
+
+	if actual := sut.rule("mp_maxrounds"); actual != 16 {
+		t.Errorf("Expected an unset round limit to follow the wingman default of 16 not %d.", actual)
+	}
+
+	if actual := NewObserver(1, 24, 6).rule("mp_maxrounds"); actual != 24 {
+		t.Errorf("Expected the specified round limit of 24 not %d.", actual)
+	}
+}
+
+func Test_NewGameModeObserver_sharesObserverSetup(t *testing.T) {
+	sut := NewGameModeObserver(0, 2)
+
+	for name, expected := range map[string]string{"mp_ct_default_secondary": "weapon_hkp2000", "mp_t_default_secondary": "weapon_glock"} {
+		if actual, _ := sut.srcdsObserver.TryCvarAsString(name, ""); actual != expected {
+			t.Errorf("Expected %q to be seeded with %q not %q.", name, expected, actual)
+		}
+	}
+
+	if actual := sut.rule("mp_maxrounds"); actual != 16 {
+		t.Errorf("Expected the wingman default of 16 rounds not %d.", actual)
+	}
+}
+
+func Test_GameModeObserver_wingmanDraw(t *testing.T) {
+	decided := []MatchDecided{}
+	halftimes := []int{}
+
+	sut := NewGameModeObserver(0, 2)
+	sut.OnEvent(func(e srcds.Event) {
+		switch v := e.(type) {
+		case Halftime:
+			halftimes = append(halftimes, v.Round)
+		case MatchDecided:
+			decided = append(decided, v)
+		}
+	})
+
+	if actual := sut.GameMode(); actual != "wingman" {
+		t.Fatalf("Expected wingman rules not %q.", actual)
+	}
+
+	lines := []string{`L 08/04/2019 - 20:00:00: World triggered "Match_Start" on "de_shortnuke"`}
+	ct, terrorist := 0, 0
+
+	// The CTs win every round, so each team wins all 8 rounds of the half it starts as CT
+	for round := 1; round <= 16; round++ {
+		if round == 9 {
+			ct, terrorist = terrorist, ct
+		}
+
+		ct++
+		lines = append(lines,
+			fmt.Sprintf(`L 08/04/2019 - 20:%02d:10: World triggered "Round_Start"`, round),
+			fmt.Sprintf(`L 08/04/2019 - 20:%02d:31: Team "CT" triggered "SFUI_Notice_CTs_Win" (CT "%d") (T "%d")`, round, ct, terrorist),
+		)
+	}
+
+	observeInto(t, sut, lines...)
+
+	if len(halftimes) != 1 || halftimes[0] != 8 {
+		t.Errorf("Expected halftime after round 8 not %v.", halftimes)
+	}
+
+	if len(decided) != 1 || !decided[0].Draw || decided[0].Team1Score != 8 || decided[0].Team2Score != 8 {
+		t.Errorf("Expected an 8-8 draw not %+v.", decided)
+	}
+}
+
+func Test_GameModeObserver_deathmatch(t *testing.T) {
+	decided := 0
+
+	sut := NewGameModeObserver(1, 2)
+	sut.OnEvent(func(e srcds.Event) {
+		if _, ok := e.(MatchDecided); ok {
+			decided++
+		}
+	})
+
+	observeInto(t, sut,
+		`L 08/04/2019 - 20:00:00: World triggered "Match_Start" on "de_dust2"`,
+		`L 08/04/2019 - 20:00:10: World triggered "Round_Start"`,
+		`L 08/04/2019 - 20:10:10: Team "CT" triggered "SFUI_Notice_CTs_Win" (CT "1") (T "0")`,
+	)
+
+	if decided != 0 {
+		t.Errorf("Expected a deathmatch not to be decided by rounds but %d match(es) were decided.", decided)
+	}
+
+	if phase, _ := sut.Phase(); phase != "round_over" {
+		t.Errorf("Expected a deathmatch never to enter overtime but the phase is %q.", phase)
+	}
+}
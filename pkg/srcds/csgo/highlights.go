@@ -0,0 +1,247 @@
+package csgo
+
+import (
+	"time"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+	"github.com/rs/zerolog/log"
+)
+
+// clutchInfo contains information about a player left alone against one or more opponents
+type clutchInfo struct {
+	at        time.Time
+	key       string
+	opponents int
+	team      team
+}
+
+// ClutchStarted is emitted when a player becomes the last one alive on their team while opponents remain
+type ClutchStarted struct {
+	srcds.EventTime
+	Match     int
+	Round     int
+	SteamID   string
+	Username  string
+	Team      string
+	Opponents int
+}
+
+// ClutchEnded is emitted when a round with a clutch situation ends
+type ClutchEnded struct {
+	srcds.EventTime
+	Match     int
+	Round     int
+	SteamID   string
+	Username  string
+	Team      string
+	Opponents int
+	Won       bool
+}
+
+// MultiKill is emitted at the end of a round for each player who reached the game mode's multi-kill threshold (three
+// kills in competitive); Ace is set if they wiped out a full opposing team
+type MultiKill struct {
+	srcds.EventTime
+	Match    int
+	Round    int
+	SteamID  string
+	Username string
+	Team     string
+	Kills    int
+	Ace      bool
+}
+
+// Accolade is emitted for each player recognized on the scoreboard (e.g. most kills, MVPs, or 5k rounds)
+type Accolade struct {
+	srcds.EventTime
+	Match    int
+	Final    bool // true if awarded at the end of the match rather than the end of a round
+	Name     string
+	Username string
+	Position int
+	Value    float64
+	Score    float64
+}
+
+// Clutch describes a player left alone against one or more opponents during a round
+type Clutch struct {
+	SteamID   string
+	Team      string
+	Opponents int
+	Won       bool
+}
+
+// markAllAlive considers every player on a team alive as the active round goes live
+func (g *gameInfo) markAllAlive() {
+	if r := g.activeRound(); r != nil {
+		r.markAllAlive()
+	}
+}
+
+// markAllAlive considers every player on a team alive as the round goes live
+func (r *roundInfo) markAllAlive() {
+	r.alive = make(map[string]team)
+
+	for key, s := range r.players {
+		if t := team(s.Team); t == mpTeam1 || t == mpTeam2 {
+			r.alive[key] = t
+		}
+	}
+}
+
+// aliveCount returns the number of players alive on the team along with one of the players
+func (r *roundInfo) aliveCount(t team) (count int, anyKey string) {
+	for key, aliveTeam := range r.alive {
+		if aliveTeam == t {
+			count++
+			anyKey = key
+		}
+	}
+
+	return count, anyKey
+}
+
+// clutchFor returns the clutch situation of a team (if any)
+func (r *roundInfo) clutchFor(t team) (clutchInfo, bool) {
+	for _, c := range r.clutches {
+		if c.team == t {
+			return c, true
+		}
+	}
+
+	return clutchInfo{}, false
+}
+
+// recordDeparture removes a player from the alive list, checking if it caused a clutch situation
+func (g *gameInfo) recordDeparture(at time.Time, key string) {
+	r := g.activeRound()
+	if r == nil || len(g.matches) == 0 || !g.matches[len(g.matches)-1].roundLive {
+		return
+	}
+
+	t, alive := r.alive[key]
+	if !alive {
+		return
+	}
+
+	delete(r.alive, key)
+
+	opponent := mpTeam1
+	if t == mpTeam1 {
+		opponent = mpTeam2
+	}
+
+	// The team that just lost a player is checked first so trades into a 1v1 go to whoever was left alone first
+	for _, pair := range [2][2]team{{t, opponent}, {opponent, t}} {
+		clutcher, against := pair[0], pair[1]
+
+		count, clutcherKey := r.aliveCount(clutcher)
+		opponents, _ := r.aliveCount(against)
+
+		if count != 1 || opponents < 1 {
+			continue
+		}
+
+		if _, found := r.clutchFor(clutcher); found {
+			continue
+		}
+
+		if _, found := r.clutchFor(against); found {
+			continue
+		}
+
+		c := clutchInfo{at: at, key: clutcherKey, opponents: opponents, team: clutcher}
+		r.clutches = append(r.clutches, c)
+
+		log.Info().Str("SteamID", clutcherKey).Msgf("%q is in a 1v%d clutch for %v", g.playerNames[clutcherKey], opponents, clutcher)
+
+		g.emit(ClutchStarted{
+			EventTime: srcds.EventTime{At: at},
+			Match:     len(g.matches),
+			Round:     int(g.currentMatchLastCompletedRound()) + 1,
+			SteamID:   clutcherKey,
+			Username:  g.playerNames[clutcherKey],
+			Team:      string(clutcher),
+			Opponents: opponents,
+		})
+	}
+}
+
+// detectHighlights emits the clutch outcomes and multi-kills for the round that is ending
+func (g *gameInfo) detectHighlights(at time.Time, winner team, mode gameMode) {
+	r := g.activeRound()
+	if r == nil {
+		return
+	}
+
+	matchNumber, roundNumber := len(g.matches), int(g.currentMatchLastCompletedRound())+1
+
+	for _, c := range r.clutches {
+		won := c.team == winner
+
+		if won {
+			log.Info().Str("SteamID", c.key).Msgf("%q won a 1v%d clutch for %v", g.playerNames[c.key], c.opponents, c.team)
+		}
+
+		g.emit(ClutchEnded{
+			EventTime: srcds.EventTime{At: at},
+			Match:     matchNumber,
+			Round:     roundNumber,
+			SteamID:   c.key,
+			Username:  g.playerNames[c.key],
+			Team:      string(c.team),
+			Opponents: c.opponents,
+			Won:       won,
+		})
+	}
+
+	kills := make(map[string]int)
+	order := []string{}
+
+	for _, k := range r.kills {
+		key := statsKey(k.attacker)
+		if _, found := kills[key]; !found {
+			order = append(order, key)
+		}
+
+		kills[key]++
+	}
+
+	for _, key := range order {
+		n := kills[key]
+		if n < mode.minMultiKill {
+			continue
+		}
+
+		e := MultiKill{
+			EventTime: srcds.EventTime{At: at},
+			Match:     matchNumber,
+			Round:     roundNumber,
+			SteamID:   key,
+			Username:  g.playerNames[key],
+			Kills:     n,
+			Ace:       n >= mode.aceKills,
+		}
+
+		if s, found := r.players[key]; found {
+			e.Team = s.Team
+		}
+
+		log.Info().Str("SteamID", key).Msgf("%q got %d kills in round %02d", e.Username, n, roundNumber)
+		g.emit(e)
+	}
+}
+
+// recordAccolade emits an accolade awarded to a player
+func (o *Observer) recordAccolade(at time.Time, a accolade) {
+	o.game.emit(Accolade{
+		EventTime: srcds.EventTime{At: at},
+		Match:     len(o.game.matches),
+		Final:     a.final,
+		Name:      a.name,
+		Username:  a.username,
+		Position:  a.position,
+		Value:     a.value,
+		Score:     a.score,
+	})
+}
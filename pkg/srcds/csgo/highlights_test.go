@@ -0,0 +1,129 @@
+package csgo
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+)
+
+const (
+	statsCharlie = `"Charlie<8><STEAM_1:0:1003><CT>"`
+	statsZulu    = `"Zulu<9><STEAM_1:0:2003><TERRORIST>"`
+)
+
+func Test_Highlights(t *testing.T) {
+	lines := []string{
+		`L 08/04/2019 - 20:00:00: World triggered "Match_Start" on "de_lltest"`,
+		`L 08/04/2019 - 20:00:01: "Alpha<3><STEAM_1:0:1001>" switched from team <Unassigned> to <CT>`,
+		`L 08/04/2019 - 20:00:01: "Bravo<4><STEAM_1:0:1002>" switched from team <Unassigned> to <CT>`,
+		`L 08/04/2019 - 20:00:01: "Charlie<8><STEAM_1:0:1003>" switched from team <Unassigned> to <CT>`,
+		`L 08/04/2019 - 20:00:01: "Xray<5><STEAM_1:0:2001>" switched from team <Unassigned> to <TERRORIST>`,
+		`L 08/04/2019 - 20:00:01: "Yankee<6><STEAM_1:0:2002>" switched from team <Unassigned> to <TERRORIST>`,
+		`L 08/04/2019 - 20:00:01: "Zulu<9><STEAM_1:0:2003>" switched from team <Unassigned> to <TERRORIST>`,
+		// Round 1; Charlie wins a 1v3 with a triple kill
+		`L 08/04/2019 - 20:00:10: World triggered "Round_Start"`,
+		`L 08/04/2019 - 20:00:20: ` + statsXray + ` [0 0 0] killed ` + statsAlpha + ` [0 0 0] with "ak47"`,
+		`L 08/04/2019 - 20:00:21: ` + statsXray + ` [0 0 0] killed ` + statsBravo + ` [0 0 0] with "ak47"`,
+		`L 08/04/2019 - 20:00:30: ` + statsCharlie + ` [0 0 0] killed ` + statsXray + ` [0 0 0] with "awp"`,
+		`L 08/04/2019 - 20:00:35: ` + statsCharlie + ` [0 0 0] killed ` + statsYanke + ` [0 0 0] with "awp"`,
+		`L 08/04/2019 - 20:00:40: ` + statsCharlie + ` [0 0 0] killed ` + statsZulu + ` [0 0 0] with "awp"`,
+		`L 08/04/2019 - 20:00:40: Team "CT" triggered "SFUI_Notice_CTs_Win" (CT "1") (T "0")`,
+		// Round 2; Charlie loses a 1v2
+		`L 08/04/2019 - 20:01:10: World triggered "Round_Start"`,
+		`L 08/04/2019 - 20:01:20: ` + statsAlpha + ` [0 0 0] killed ` + statsXray + ` [0 0 0] with "m4a1"`,
+		`L 08/04/2019 - 20:01:21: ` + statsYanke + ` [0 0 0] killed ` + statsAlpha + ` [0 0 0] with "ak47"`,
+		`L 08/04/2019 - 20:01:25: "Bravo<4><STEAM_1:0:1002><CT>" disconnected (reason "Disconnect")`,
+		`L 08/04/2019 - 20:01:50: Team "TERRORIST" triggered "SFUI_Notice_Target_Bombed" (CT "1") (T "1")`,
+	}
+
+	sut := NewObserver(1, 30, 6)
+
+	var events []srcds.Event
+	sut.OnEvent(func(e srcds.Event) {
+		switch e.(type) {
+		case ClutchStarted, ClutchEnded, MultiKill:
+			events = append(events, e)
+		}
+
+		// Handlers must be able to query the observer without deadlocking
+		sut.CurrentMatch()
+	})
+
+	sut.Read(strings.NewReader(strings.Join(lines, "\n") + "\n"))
+	sut.Wait()
+
+	expected := []srcds.Event{
+		ClutchStarted{Match: 1, Round: 1, SteamID: "STEAM_1:0:1003", Username: "Charlie", Team: string(mpTeam1), Opponents: 3},
+		ClutchEnded{Match: 1, Round: 1, SteamID: "STEAM_1:0:1003", Username: "Charlie", Team: string(mpTeam1), Opponents: 3, Won: true},
+		MultiKill{Match: 1, Round: 1, SteamID: "STEAM_1:0:1003", Username: "Charlie", Team: string(mpTeam1), Kills: 3},
+		ClutchStarted{Match: 1, Round: 2, SteamID: "STEAM_1:0:1003", Username: "Charlie", Team: string(mpTeam1), Opponents: 2},
+		ClutchEnded{Match: 1, Round: 2, SteamID: "STEAM_1:0:1003", Username: "Charlie", Team: string(mpTeam1), Opponents: 2, Won: false},
+	}
+
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events but got %d: %+v", len(expected), len(events), events)
+	}
+
+	for i, e := range events {
+		if e.Time().IsZero() {
+			t.Errorf("Event %d should have a timestamp: %+v", i, e)
+		}
+
+		// Timestamps are verified above; compare everything else
+		switch actual := e.(type) {
+		case ClutchStarted:
+			actual.At = expected[i].(ClutchStarted).At
+			e = actual
+		case ClutchEnded:
+			actual.At = expected[i].(ClutchEnded).At
+			e = actual
+		case MultiKill:
+			actual.At = expected[i].(MultiKill).At
+			e = actual
+		}
+
+		if e != expected[i] {
+			t.Errorf("Expected event %d to be %+v not %+v.", i, expected[i], e)
+		}
+	}
+
+	match, _ := sut.CurrentMatch()
+	if clutches := match.Rounds[0].Clutches; len(clutches) != 1 || !clutches[0].Won || clutches[0].Opponents != 3 {
+		t.Errorf("Round 1 clutch was not recorded correctly: %+v", clutches)
+	}
+
+	if clutches := match.Rounds[1].Clutches; len(clutches) != 1 || clutches[0].Won {
+		t.Errorf("Round 2 clutch was not recorded correctly: %+v", clutches)
+	}
+}
+
+func Test_Highlights_wingmanAce(t *testing.T) {
+	lines := []string{
+		`L 08/04/2019 - 20:00:00: World triggered "Match_Start" on "de_lake"`,
+		`L 08/04/2019 - 20:00:01: "Alpha<3><STEAM_1:0:1001>" switched from team <Unassigned> to <CT>`,
+		`L 08/04/2019 - 20:00:01: "Bravo<4><STEAM_1:0:1002>" switched from team <Unassigned> to <CT>`,
+		`L 08/04/2019 - 20:00:01: "Xray<5><STEAM_1:0:2001>" switched from team <Unassigned> to <TERRORIST>`,
+		`L 08/04/2019 - 20:00:01: "Yankee<6><STEAM_1:0:2002>" switched from team <Unassigned> to <TERRORIST>`,
+		`L 08/04/2019 - 20:00:10: World triggered "Round_Start"`,
+		`L 08/04/2019 - 20:00:20: ` + statsAlpha + ` [0 0 0] killed ` + statsXray + ` [0 0 0] with "m4a1"`,
+		`L 08/04/2019 - 20:00:21: ` + statsAlpha + ` [0 0 0] killed ` + statsYanke + ` [0 0 0] with "m4a1"`,
+		`L 08/04/2019 - 20:00:21: Team "CT" triggered "SFUI_Notice_CTs_Win" (CT "1") (T "0")`,
+	}
+
+	sut := NewGameModeObserver(0, 2)
+
+	var multiKills []MultiKill
+	sut.OnEvent(func(e srcds.Event) {
+		if mk, ok := e.(MultiKill); ok {
+			multiKills = append(multiKills, mk)
+		}
+	})
+
+	sut.Read(strings.NewReader(strings.Join(lines, "\n") + "\n"))
+	sut.Wait()
+
+	if len(multiKills) != 1 || multiKills[0].Kills != 2 || !multiKills[0].Ace {
+		t.Errorf("Expected wiping out the other wingman team to be an ace not %+v.", multiKills)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
 	"github.com/rs/zerolog"
 )
 
@@ -61,6 +62,15 @@ func Test_ParseLogs(t *testing.T) {
 			r := bufio.NewReader(file)
 
 			sut := NewObserver(test.mpHalftime, test.mpMaxRounds, test.mpMaxOvertimeRounds)
+
+			discrepancies := 0
+			sut.OnEvent(func(e srcds.Event) {
+				if d, ok := e.(ScoreDiscrepancy); ok {
+					discrepancies++
+					t.Logf("Unexpected score discrepancy: %+v", d)
+				}
+			})
+
 			sut.Read(r)
 			sut.Wait()
 
@@ -72,6 +82,10 @@ func Test_ParseLogs(t *testing.T) {
 				t.Errorf("Expected %02d rounds to have been completed, not %02d.", test.expected.roundsCompleted, sut.statistics.roundsCompleted)
 			}
 
+			if discrepancies != 0 {
+				t.Errorf("Expected the computed scores to always agree with the server, not to disagree %d times.", discrepancies)
+			}
+
 			//if csgo.statistics.matchesStarted != test.expected.matchesStarted {
 			//	t.Errorf("Expected %02d matches to have been started but saw %02d", test.expected.matchesStarted, csgo.statistics.matchesStarted)
 			//}
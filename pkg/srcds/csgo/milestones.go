@@ -0,0 +1,164 @@
+package csgo
+
+import (
+	"time"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+	"github.com/rs/zerolog/log"
+)
+
+// Halftime is emitted when the round that was just completed ends a half and sides will be switched
+type Halftime struct {
+	srcds.EventTime
+	Match int
+	Round int // the last round of the half
+}
+
+// LastRoundOfHalf is emitted when the next round will be the last of a half (or of regulation or an overtime period)
+type LastRoundOfHalf struct {
+	srcds.EventTime
+	Match int
+	Round int
+}
+
+// MatchDecided is emitted when the current match is won or drawn
+type MatchDecided struct {
+	srcds.EventTime
+	Match      int
+	Winner     string // mp_team1 or mp_team2; empty if the match was a draw
+	WinnerName string
+	Draw       bool
+	Team1Score int
+	Team2Score int
+}
+
+// MatchPoint is emitted for each team that will clinch the match by winning the next round
+type MatchPoint struct {
+	srcds.EventTime
+	Match    int
+	Round    int
+	Team     string
+	TeamName string
+}
+
+// OvertimeStarted is emitted when the next round begins an overtime period
+type OvertimeStarted struct {
+	srcds.EventTime
+	Match  int
+	Period int
+}
+
+// matchOutcome determines if the current match is over and which team won it (if the match wasn't a draw)
+func (o *Observer) matchOutcome() (over bool, winner team) {
+	mpTeam1Wins, mpTeam2Wins := o.game.scoresCurrentMatch()
+	return o.matchOutcomeWithScores(mpTeam1Wins, mpTeam2Wins)
+}
+
+// matchOutcomeWithScores determines if a match with the specified scores is over and which team won it; matches of
+// game modes that aren't round based are only over once the server reports the game is over
+func (o *Observer) matchOutcomeWithScores(mpTeam1Wins, mpTeam2Wins lastInt) (over bool, winner team) {
+	if !o.gameMode().roundBased {
+		return false, ""
+	}
+
+	mpMaxrounds := o.rule("mp_maxrounds")
+	mpOvertimeMaxrounds := o.rule("mp_overtime_maxrounds")
+	mpOvertimeEnable := o.rule("mp_overtime_enable")
+	mpMatchCanClinch := o.rule("mp_match_can_clinch")
+
+	return calculateMatchOutcome(mpMaxrounds, mpOvertimeMaxrounds, mpOvertimeEnable, mpMatchCanClinch, mpTeam1Wins, mpTeam2Wins)
+}
+
+// decideMatch records the outcome of the current match
+func (o *Observer) decideMatch(at time.Time, winner team) {
+	if len(o.game.matches) == 0 {
+		return
+	}
+
+	matchNum := len(o.game.matches)
+	m := &o.game.matches[matchNum-1]
+	m.decided, m.winner = true, winner
+
+	mpTeam1Wins, mpTeam2Wins := o.game.scoresCurrentMatch()
+	roundNum := int(o.game.currentMatchLastCompletedRound())
+
+	if winner == "" {
+		log.Info().Int("match", matchNum).Int("round", roundNum).Int("team1_score", int(mpTeam1Wins)).Int("team2_score", int(mpTeam2Wins)).Msgf("Match %02d ended in a draw", matchNum)
+	} else {
+		log.Info().Int("match", matchNum).Int("round", roundNum).Int("team1_score", int(mpTeam1Wins)).Int("team2_score", int(mpTeam2Wins)).Msgf("Match %02d clinched by %v (%v)", matchNum, winner, o.game.teamName(winner))
+	}
+
+	o.game.emit(MatchDecided{
+		EventTime:  srcds.EventTime{At: at},
+		Match:      matchNum,
+		Winner:     string(winner),
+		WinnerName: o.game.teamName(winner),
+		Draw:       winner == "",
+		Team1Score: int(mpTeam1Wins),
+		Team2Score: int(mpTeam2Wins),
+	})
+}
+
+// emitRoundMilestones emits the halftime, overtime, and match point events due after a round that didn't end the match
+func (o *Observer) emitRoundMilestones(at time.Time) {
+	if !o.gameMode().roundBased {
+		return
+	}
+
+	mpHalftime := o.rule("mp_halftime")
+	mpMaxrounds := o.rule("mp_maxrounds")
+	mpOvertimeMaxrounds := o.rule("mp_overtime_maxrounds")
+
+	if mpMaxrounds < 1 {
+		mpMaxrounds = defaultMpMaxrounds
+	}
+
+	if mpOvertimeMaxrounds < 1 {
+		mpOvertimeMaxrounds = defaultMpOvertimeMaxrounds
+	}
+
+	matchNum := len(o.game.matches)
+	lastCompletedRound := o.game.currentMatchLastCompletedRound()
+	nextRound := lastCompletedRound + 1
+
+	if calculateIsHalftime(mpHalftime, mpMaxrounds, mpOvertimeMaxrounds, lastCompletedRound) {
+		log.Info().Int("match", matchNum).Int("round", int(lastCompletedRound)).Msg("Halftime")
+		o.game.emit(Halftime{EventTime: srcds.EventTime{At: at}, Match: matchNum, Round: int(lastCompletedRound)})
+	}
+
+	mpOvertimeEnable := o.rule("mp_overtime_enable")
+
+	if otRounds := int(lastCompletedRound) - mpMaxrounds; mpOvertimeEnable != 0 && otRounds >= 0 && otRounds%mpOvertimeMaxrounds == 0 {
+		period := calcOvertimePeriodNumber(mpMaxrounds, mpOvertimeMaxrounds, lastCompletedRound)
+
+		log.Info().Int("match", matchNum).Int("round", int(nextRound)).Msgf("Overtime period %d is starting", period)
+		o.game.emit(OvertimeStarted{EventTime: srcds.EventTime{At: at}, Match: matchNum, Period: period})
+	}
+
+	mpTeam1Wins, mpTeam2Wins := o.game.scoresCurrentMatch()
+
+	for _, s := range []struct {
+		team         team
+		team1, team2 lastInt
+	}{{mpTeam1, mpTeam1Wins + 1, mpTeam2Wins}, {mpTeam2, mpTeam1Wins, mpTeam2Wins + 1}} {
+		if over, winner := o.matchOutcomeWithScores(s.team1, s.team2); !over || winner != s.team {
+			continue
+		}
+
+		log.Info().Int("match", matchNum).Int("round", int(nextRound)).Msgf("Match point for %v (%v)", s.team, o.game.teamName(s.team))
+		o.game.emit(MatchPoint{
+			EventTime: srcds.EventTime{At: at},
+			Match:     matchNum,
+			Round:     int(nextRound),
+			Team:      string(s.team),
+			TeamName:  o.game.teamName(s.team),
+		})
+	}
+
+	endsRegulation := int(nextRound) == mpMaxrounds
+	endsOvertimePeriod := int(nextRound) > mpMaxrounds && (int(nextRound)-mpMaxrounds)%mpOvertimeMaxrounds == 0
+
+	if endsRegulation || endsOvertimePeriod || calculateIsHalftime(mpHalftime, mpMaxrounds, mpOvertimeMaxrounds, nextRound) {
+		o.game.emit(LastRoundOfHalf{EventTime: srcds.EventTime{At: at}, Match: matchNum, Round: int(nextRound)})
+	}
+}
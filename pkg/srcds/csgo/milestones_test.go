@@ -0,0 +1,161 @@
+package csgo
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+)
+
+func Test_RoundMilestones(t *testing.T) {
+	file, err := os.Open("./testdata/mpteam1_overtimewin.log")
+	if err != nil {
+		t.Fatalf("Could not open log file: %v", err)
+	}
+	defer file.Close()
+
+	actual := []string{}
+
+	sut := NewObserver(1, 30, 7)
+	sut.OnEvent(func(e srcds.Event) {
+		switch v := e.(type) {
+		case Halftime:
+			actual = append(actual, fmt.Sprintf("halftime after %d", v.Round))
+		case LastRoundOfHalf:
+			actual = append(actual, fmt.Sprintf("last round of half %d", v.Round))
+		case MatchPoint:
+			actual = append(actual, fmt.Sprintf("match point %d for %s", v.Round, v.TeamName))
+		case OvertimeStarted:
+			actual = append(actual, fmt.Sprintf("overtime period %d", v.Period))
+		}
+	})
+	sut.Read(file)
+	sut.Wait()
+
+	expected := []string{
+		"last round of half 15",
+		"halftime after 15",
+		"match point 30 for ALPHA",
+		"last round of half 30",
+		"overtime period 1",
+		"last round of half 33",
+		"halftime after 33",
+		"match point 35 for ALPHA",
+	}
+
+	if len(actual) != len(expected) {
+		t.Fatalf("Expected events %q not %q.", expected, actual)
+	}
+
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("Expected event %q not %q.", expected[i], actual[i])
+		}
+	}
+}
+
+func Test_RoundMilestones_bothTeamsAtMatchPoint(t *testing.T) {
+	points := []MatchPoint{}
+
+	sut := NewObserver(1, 3, 2)
+	sut.OnEvent(func(e srcds.Event) {
+		if v, ok := e.(MatchPoint); ok {
+			points = append(points, v)
+		}
+	})
+
+	observeInto(t, sut,
+		`L 08/04/2019 - 20:00:00: World triggered "Match_Start" on "de_lltest"`,
+		`L 08/04/2019 - 20:00:10: World triggered "Round_Start"`,
+		`L 08/04/2019 - 20:00:31: Team "CT" triggered "SFUI_Notice_CTs_Win" (CT "1") (T "0")`,
+		// Sides are switched after the first round
+		`L 08/04/2019 - 20:01:10: World triggered "Round_Start"`,
+		`L 08/04/2019 - 20:01:31: Team "CT" triggered "SFUI_Notice_CTs_Win" (CT "1") (T "1")`,
+	)
+
+	if len(points) != 3 {
+		t.Fatalf("Expected 3 match points not %d: %+v", len(points), points)
+	}
+
+	if points[0].Round != 2 || points[0].Team != string(mpTeam1) {
+		t.Errorf("Expected mp_team1 to be at match point for round 2 not %+v.", points[0])
+	}
+
+	if points[1].Round != 3 || points[2].Round != 3 || points[1].Team == points[2].Team {
+		t.Errorf("Expected both teams to be at match point for round 3 not %+v.", points[1:])
+	}
+}
+
+func Test_MatchDecided_drawWithoutOvertime(t *testing.T) {
+	decided := []MatchDecided{}
+
+	sut := NewObserver(1, 2, 2)
+	sut.OnEvent(func(e srcds.Event) {
+		if v, ok := e.(MatchDecided); ok {
+			decided = append(decided, v)
+		}
+	})
+
+	observeInto(t, sut,
+		`L 08/04/2019 - 20:00:00: server_cvar: "mp_overtime_enable" "0"`,
+		`L 08/04/2019 - 20:00:00: World triggered "Match_Start" on "de_lltest"`,
+		`L 08/04/2019 - 20:00:10: World triggered "Round_Start"`,
+		`L 08/04/2019 - 20:00:31: Team "CT" triggered "SFUI_Notice_CTs_Win" (CT "1") (T "0")`,
+		`L 08/04/2019 - 20:01:10: World triggered "Round_Start"`,
+		`L 08/04/2019 - 20:01:31: Team "CT" triggered "SFUI_Notice_CTs_Win" (CT "1") (T "1")`,
+	)
+
+	if len(decided) != 1 || !decided[0].Draw || decided[0].Winner != "" {
+		t.Fatalf("Expected the match to be drawn not %+v.", decided)
+	}
+
+	if m, _ := sut.CurrentMatch(); !m.Decided || m.Winner != "" {
+		t.Errorf("Expected the match snapshot to be drawn not decided %v with winner %q.", m.Decided, m.Winner)
+	}
+
+	if phase, _ := sut.Phase(); phase != "round_over" {
+		t.Errorf("Expected a drawn match not to enter overtime but the phase is %q.", phase)
+	}
+}
+
+func Test_MatchDecided_cannotClinch(t *testing.T) {
+	decided := []MatchDecided{}
+	points := []MatchPoint{}
+
+	sut := NewObserver(1, 3, 2)
+	sut.OnEvent(func(e srcds.Event) {
+		switch v := e.(type) {
+		case MatchDecided:
+			decided = append(decided, v)
+		case MatchPoint:
+			points = append(points, v)
+		}
+	})
+
+	observeInto(t, sut,
+		`L 08/04/2019 - 20:00:00: server_cvar: "mp_match_can_clinch" "0"`,
+		`L 08/04/2019 - 20:00:00: World triggered "Match_Start" on "de_lltest"`,
+		`L 08/04/2019 - 20:00:10: World triggered "Round_Start"`,
+		`L 08/04/2019 - 20:00:31: Team "CT" triggered "SFUI_Notice_CTs_Win" (CT "1") (T "0")`,
+		`L 08/04/2019 - 20:01:10: World triggered "Round_Start"`,
+		`L 08/04/2019 - 20:01:31: Team "TERRORIST" triggered "SFUI_Notice_Terrorists_Win" (CT "0") (T "2")`,
+	)
+
+	if len(decided) != 0 {
+		t.Fatalf("Expected the match not to be clinched after 2 of 3 rounds not %+v.", decided)
+	}
+
+	if len(points) != 1 || points[0].Round != 3 {
+		t.Errorf("Expected a single match point for the final round when every round is played not %+v.", points)
+	}
+
+	observeInto(t, sut,
+		`L 08/04/2019 - 20:02:10: World triggered "Round_Start"`,
+		`L 08/04/2019 - 20:02:31: Team "CT" triggered "SFUI_Notice_CTs_Win" (CT "1") (T "2")`,
+	)
+
+	if len(decided) != 1 || decided[0].Draw || decided[0].Team1Score != 2 {
+		t.Errorf("Expected mp_team1 to win the match after every round was played not %+v.", decided)
+	}
+}
@@ -2,6 +2,7 @@ package csgo
 
 import (
 	"io"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -9,15 +10,39 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-// NewObserver for observing CSGO log streams
+// NewObserver for observing CSGO log streams; round limits less than 1 fall back to the game mode's defaults
 func NewObserver(mpHalftime, mpMaxRounds, mpMaxOvertimeRounds int) *Observer {
+	o := newObserver()
+
+	o.srcdsObserver.AddCvarWatcherDefault("mp_halftime", strconv.Itoa(mpHalftime))
+
+	if mpMaxRounds > 0 {
+		o.srcdsObserver.AddCvarWatcherDefault("mp_maxrounds", strconv.Itoa(mpMaxRounds))
+	}
+
+	if mpMaxOvertimeRounds > 0 {
+		o.srcdsObserver.AddCvarWatcherDefault("mp_overtime_maxrounds", strconv.Itoa(mpMaxOvertimeRounds))
+	}
+
+	o.srcdsObserver.AddCvarWatcherDefault("mp_do_warmup", strconv.Itoa(defaultMpDoWarmupPeriod))
+	o.srcdsObserver.AddCvarWatcherDefault("mp_overtime_enable", strconv.Itoa(defaultMpOvertimeEnabled))
+	o.srcdsObserver.AddCvarWatcherDefault("mp_match_can_clinch", strconv.Itoa(defaultMpMatchCanClinch))
+
+	return o
+}
+
+// newObserver creates an observer watching every rule cvar; unseeded rules fall back to the game mode's defaults until
+// the log stream reports them
+func newObserver() *Observer {
 	o := &Observer{
 		srcdsObserver: srcds.NewObserver(),
 	}
 
-	o.srcdsObserver.AddCvarWatcherDefault("mp_halftime", string(mpHalftime))
-	o.srcdsObserver.AddCvarWatcherDefault("mp_maxrounds", string(mpMaxRounds))
-	o.srcdsObserver.AddCvarWatcherDefault("mp_overtime_maxrounds", string(mpMaxOvertimeRounds))
+	o.srcdsObserver.AddCvarWatcher("game_type", "game_mode", "mp_halftime", "mp_maxrounds", "mp_overtime_maxrounds", "mp_do_warmup",
+		"mp_overtime_enable", "mp_match_can_clinch")
+	o.srcdsObserver.AddCvarWatcherDefault("mp_ct_default_secondary", "weapon_hkp2000")
+	o.srcdsObserver.AddCvarWatcherDefault("mp_t_default_secondary", "weapon_glock")
+	o.srcdsObserver.OnConsoleLine(o.processConsoleLine)
 
 	return o
 }
@@ -32,7 +57,8 @@ func (o *Observer) Read(r io.Reader) {
 	}()
 }
 
-// Listen to a CSGO log output stream
+// Listen to a CSGO log output stream; the stream must be drained as the observer blocks while it is full,
+// consumers that may fall behind should Subscribe instead
 func (o *Observer) Listen(r io.Reader) <-chan srcds.LogEntry {
 	logStream := make(chan srcds.LogEntry, 6)
 	o.waitGroup.Add(1)
@@ -54,17 +80,38 @@ func (o *Observer) Wait() {
 	o.waitGroup.Wait()
 }
 
-//Observer for watching CSGO log streams
+// RegisterParser adds a parser that is offered every log entry before the CSGO observer processes it; parsers
+// registered while the log stream is being observed are offered the log entries that follow
+func (o *Observer) RegisterParser(p srcds.LineParser) error {
+	return o.srcdsObserver.RegisterParser(p)
+}
+
+// Subscribe to the log stream; subscribers receive each log entry before the CSGO observer processes it
+func (o *Observer) Subscribe(name string, size int, policy srcds.OverflowPolicy) *srcds.Subscription {
+	return o.srcdsObserver.Subscribe(name, size, policy)
+}
+
+// AddRuleDefault sets the value a rule cvar (e.g. mp_maxrounds) is assumed to have until the log stream reports its own;
+// for games that log like CSGO but play by different rules
+func (o *Observer) AddRuleDefault(name string, value int) {
+	o.srcdsObserver.AddCvarWatcherDefault(name, strconv.Itoa(value))
+}
+
+// Observer for watching CSGO log streams
 type Observer struct {
 	players struct {
 		mpTeam1    srcds.Clients
 		mpTeam2    srcds.Clients
 		unassigned srcds.Clients
 	}
-	game          gameInfo
-	srcdsObserver *srcds.Observer
-	statistics    observerStatistics
-	waitGroup     sync.WaitGroup
+	backupFiles         map[int]string // keyed by the number of rounds completed
+	game                gameInfo
+	logEntriesProcessed uint64
+	mux                 sync.Mutex
+	pendingConsoleLines []srcds.ConsoleLine
+	srcdsObserver       *srcds.Observer
+	statistics          observerStatistics
+	waitGroup           sync.WaitGroup
 }
 
 type observerStatistics struct {
@@ -75,7 +122,75 @@ type observerStatistics struct {
 
 // processLogEntry and apply it to CSGO
 func (o *Observer) processLogEntry(le srcds.LogEntry) {
+	o.mux.Lock()
+	o.applyLogEntry(le)
+	o.logEntriesProcessed++
+	o.applyPendingConsoleLines()
+	o.mux.Unlock()
+
+	o.game.events.Dispatch()
+}
+
+// applyLogEntry updates the observed game state; the caller must hold the observer's lock
+func (o *Observer) applyLogEntry(le srcds.LogEntry) {
 	if clientLog, ok := srcds.ParseClientLogEntry(le); ok {
+		o.game.sawClient(clientLog.Client)
+
+		if m, ok := parseClientAttacked(clientLog); ok {
+			o.game.recordDamage(clientLog.Client, m)
+			return
+		}
+
+		if m, ok := parseClientKilled(clientLog); ok {
+			o.game.recordKill(le.Timestamp, clientLog.Client, m)
+			return
+		}
+
+		if g, ok := parseClientThrew(clientLog); ok {
+			o.game.recordGrenadeThrown(clientLog.Client, g)
+			return
+		}
+
+		if m, ok := parseClientBlinded(clientLog); ok {
+			o.game.recordBlinded(clientLog.Client, m)
+			return
+		}
+
+		if m, ok := parseClientMoneyChange(clientLog); ok {
+			o.game.recordMoneyChange(clientLog.Client, m)
+			return
+		}
+
+		if item, ok := parseClientPurchased(clientLog); ok {
+			o.game.recordPurchase(clientLog.Client, item)
+			return
+		}
+
+		if equipment, ok := parseClientLeftBuyzone(clientLog); ok {
+			o.game.recordEquipment(clientLog.Client, equipment)
+			return
+		}
+
+		if m, ok := parseClientBombEvent(clientLog); ok {
+			o.game.recordBombEvent(le.Timestamp, clientLog.Client, m)
+			return
+		}
+
+		if m, ok := parseClientAssisted(clientLog); ok {
+			o.game.recordAssist(clientLog.Client, m)
+			return
+		}
+
+		if _, ok := parseClientSuicide(clientLog); ok {
+			o.game.recordDeath(le.Timestamp, clientLog.Client)
+			return
+		}
+
+		if parseClientKilledByBomb(clientLog) {
+			o.game.recordDeath(le.Timestamp, clientLog.Client)
+			return
+		}
+
 		if _, ok := parseClientSay(clientLog); ok {
 			// TODO: process the client saying something
 			return
@@ -92,61 +207,113 @@ func (o *Observer) processLogEntry(le srcds.LogEntry) {
 		}
 
 		if _, ok := srcds.ParseClientDisconnected(clientLog); ok {
+			o.game.recordDeparture(le.Timestamp, statsKey(clientLog.Client))
 			o.playerDropped(clientLog.Client)
 		}
 
 		return
 	}
 
+	if b, ok := parseBackupRestore(le.Message); ok {
+		o.game.restoreRound(le.Timestamp, b)
+		return
+	}
+
+	if stats, ok := srcds.ParseRoundStats(le); ok {
+		o.game.attachRoundStats(stats)
+		return
+	}
+
+	if p, ok := parsePauseCommand(le.Message); ok {
+		o.game.setPhaseModifier(le.Timestamp, paused, p)
+		return
+	}
+
 	if parseStartingFreezePeriod(le) {
 		log.Info().Msg("Starting Freeze Period")
+		o.game.prepareRound()
+		o.phaseFreezePeriod(le.Timestamp)
 		return
 	}
 
 	if worldLog, ok := parseWorldTrigger(le); ok {
 		if mapName, ok := parseWorldTriggerMatchStart(worldLog); ok {
 			o.game.nextMatch(mapName, le.Timestamp)
+			o.phaseMatchStart(le.Timestamp)
 		}
 
 		if parseWorldTriggerRoundStart(worldLog) {
 			log.Info().Msg("Round Start")
 			o.statistics.roundsStarted++
+			o.game.startRound(le.Timestamp)
+			o.enrollPlayers()
+			o.game.markAllAlive()
+			o.phaseRoundStart(le.Timestamp)
 		}
 
 		if parseWorldTriggerGameCommencing(worldLog) {
 			log.Info().Msg("Game Commencing")
+			o.phaseGameCommencing(le.Timestamp)
 		}
 
 		return
 	}
 
+	if _, ok := srcds.ParseLoadingMap(le); ok {
+		o.game.transition(le.Timestamp, mapChanging)
+		return
+	}
+
+	if _, ok := srcds.ParseStartedMap(le); ok {
+		o.game.transition(le.Timestamp, preWarmup)
+		return
+	}
+
 	if strings.HasPrefix(le.Message, "Team") {
 		if msg, ok := parseTeamTriggered(le); ok {
+			if o.game.phase.primary() == knife {
+				// Knife rounds only decide sides; they aren't part of the match
+				log.Info().Msgf("Knife round won by %v", msg.affiliation)
+				o.game.discardKnifeRound()
+				o.phaseRoundOver(le.Timestamp)
+				return
+			}
+
+			o.reconcileRoundEnd(le.Timestamp, msg)
+
 			team := o.getTeam(msg.affiliation)
+			o.enrollPlayers()
+			o.game.classifyEconomy(o.isPistolRound())
+			o.game.detectHighlights(le.Timestamp, team, o.gameMode())
 			o.game.setRoundWinner(msg.affiliation, team, msg.trigger)
 			o.statistics.roundsCompleted++
+			o.phaseRoundOver(le.Timestamp)
 
 			// Let's see if a team won
-			maxrounds, _ := o.srcdsObserver.TryCvarAsInt("mp_maxrounds", defaultMpMaxrounds)
-			otMaxrounds, _ := o.srcdsObserver.TryCvarAsInt("mp_overtime_maxrounds", defaultMpOvertimeMaxrounds)
+			if over, winner := o.matchOutcome(); over {
+				o.decideMatch(le.Timestamp, winner)
+				return
+			}
 
-			if winThreshold := calculateLastRoundWinThreshold(maxrounds, otMaxrounds, o.game.currentMatchLastCompletedRound()); o.game.currentMatchLastCompletedRound() >= winThreshold {
-				mpTeam1Wins, mpTeam2Wins := o.game.scoresCurrentMatch()
-				winningTeam := mpTeam1
+			o.emitRoundMilestones(le.Timestamp)
+			return
+		}
 
-				switch {
-				case mpTeam2Wins >= winThreshold:
-					winningTeam = mpTeam2
-				case mpTeam1Wins < winThreshold:
-					return
-				}
+		if msg, ok := parseTeamScored(le); ok {
+			o.reconcileTeamScored(le.Timestamp, msg)
+			return
+		}
 
-				matchNum := len(o.game.matches)
-				roundNum := int(o.game.currentMatchLastCompletedRound())
+		if msg, ok := parseTeamSetName(le); ok {
+			o.setTeamname(msg.affiliation, msg.teamName)
+		}
 
-				log.Info().Int("match", matchNum).Int("round", roundNum).Int("team1_score", int(mpTeam1Wins)).Int("team2_score", int(mpTeam2Wins)).Msgf("Match %02d clinched by %v (%v)", matchNum, winningTeam, o.game.teamName(winningTeam))
-			}
+		return
+	}
 
+	if strings.HasPrefix(le.Message, "MatchStatus: ") {
+		if msg, ok := parseMatchStatusScore(le); ok {
+			o.reconcileMatchStatus(le.Timestamp, msg)
 			return
 		}
 
@@ -157,6 +324,18 @@ func (o *Observer) processLogEntry(le srcds.LogEntry) {
 		return
 	}
 
+	if msg, ok := parseAccolade(le); ok {
+		o.recordAccolade(le.Timestamp, msg)
+		return
+	}
+
+	if msg, ok := parseGameOver(le); ok {
+		log.Info().Msgf("Game over on map %q with a score of %d:%d", msg.mapName, msg.score1, msg.score2)
+		o.reconcileGameOver(le.Timestamp, msg)
+		o.game.transition(le.Timestamp, matchOver|o.game.phase&overtime)
+		return
+	}
+
 	// WarMod Warning
 	if strings.HasPrefix(le.Message, "[WarMod_BFG]") {
 		if strings.Contains(le.Message, `", "event": "log_start", `) {
@@ -165,6 +344,25 @@ func (o *Observer) processLogEntry(le srcds.LogEntry) {
 	}
 }
 
+// enrollPlayers ensures every player currently on a team has statistics for the active round
+func (o *Observer) enrollPlayers() {
+	for _, c := range o.players.mpTeam1 {
+		o.game.enrollPlayer(c, mpTeam1)
+	}
+
+	for _, c := range o.players.mpTeam2 {
+		o.game.enrollPlayer(c, mpTeam2)
+	}
+}
+
+// isPistolRound determines if the current round is the first round of either half of regulation
+func (o *Observer) isPistolRound() bool {
+	mpHalftime := o.rule("mp_halftime")
+	mpMaxrounds := o.rule("mp_maxrounds")
+
+	return calculateIsPistolRound(mpHalftime, mpMaxrounds, o.game.currentMatchLastCompletedRound())
+}
+
 // getTeam returns the team (mp_team1 / mp_team2 / unassigned)
 // TODO: -- needs unit tests
 func (o *Observer) getTeam(aff affiliation) team {
@@ -172,9 +370,9 @@ func (o *Observer) getTeam(aff affiliation) team {
 		return ""
 	}
 
-	mpHalftime, _ := o.srcdsObserver.TryCvarAsInt("mp_halftime", defaultMpHalftime)
-	mpMaxrounds, _ := o.srcdsObserver.TryCvarAsInt("mp_maxrounds", defaultMpMaxrounds)
-	mpOvertimeMaxrounds, _ := o.srcdsObserver.TryCvarAsInt("mp_overtime_maxrounds", defaultMpOvertimeMaxrounds)
+	mpHalftime := o.rule("mp_halftime")
+	mpMaxrounds := o.rule("mp_maxrounds")
+	mpOvertimeMaxrounds := o.rule("mp_overtime_maxrounds")
 	completedRounds := o.game.currentMatchLastCompletedRound()
 
 	if calculateSidesAreCurrentlySwitched(mpHalftime, mpMaxrounds, mpOvertimeMaxrounds, completedRounds) {
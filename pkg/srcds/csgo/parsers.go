@@ -8,6 +8,37 @@ import (
 	"github.com/lacledeslan/sourceseer/pkg/srcds"
 )
 
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+var accoladeRegex = regexp.MustCompile(`^ACCOLADE, (FINAL|ROUND): \{(\w+)\},\s+(.+?)<(\d+)>,\s+VALUE: (-?[\d.]+),\s+POS: (\d+),\s+SCORE: (-?[\d.]+)$`)
+
+// accolade is sent for each player recognized on the scoreboard at the end of a match (e.g. most kills or MVPs)
+type accolade struct {
+	final    bool
+	name     string
+	username string
+	position int
+	value    float64
+	score    float64
+}
+
+func parseAccolade(le srcds.LogEntry) (accolade, bool) {
+	tokens := accoladeRegex.FindStringSubmatch(le.Message)
+
+	if len(tokens) != 8 {
+		return accolade{}, false
+	}
+
+	r := accolade{}
+	r.score, _ = strconv.ParseFloat(tokens[7], 64)
+	r.position, _ = strconv.Atoi(tokens[6])
+	r.value, _ = strconv.ParseFloat(tokens[5], 64)
+	r.username = tokens[3]
+	r.name = tokens[2]
+	r.final = tokens[1] == "FINAL"
+
+	return r, true
+}
+
 ///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 func parseAffiliation(af string) (affiliation affiliation, ok bool) {
 	switch a := strings.ToUpper(strings.TrimSpace(af)); a {
@@ -24,6 +55,273 @@ func parseAffiliation(af string) (affiliation affiliation, ok bool) {
 	return unassigned, false
 }
 
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+var backupFileRegex = regexp.MustCompile(`([\w.-]*round(\d{2,})\.txt)`)
+
+// backupFile is a round backup written by the server; rounds is the number of rounds that had been completed
+type backupFile struct {
+	name   string
+	rounds int
+}
+
+func parseBackupFile(line string) (backupFile, bool) {
+	tokens := backupFileRegex.FindStringSubmatch(line)
+
+	if len(tokens) != 3 {
+		return backupFile{}, false
+	}
+
+	rounds, _ := strconv.Atoi(tokens[2])
+
+	return backupFile{name: tokens[1], rounds: rounds}, true
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+var backupRestoreRegex = regexp.MustCompile(`mp_backup_restore_load_file\s+"?([\w.-]*round(\d{2,})\.txt)"?`)
+
+func parseBackupRestore(line string) (backupFile, bool) {
+	tokens := backupRestoreRegex.FindStringSubmatch(line)
+
+	if len(tokens) != 3 {
+		return backupFile{}, false
+	}
+
+	rounds, _ := strconv.Atoi(tokens[2])
+
+	return backupFile{name: tokens[1], rounds: rounds}, true
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+var clientAssistedRegex = regexp.MustCompile(`^(assisted|flash-assisted) killing (".+")$`)
+
+// clientAssisted is sent whenever a client assists (or flash-assists) in killing another client
+type clientAssisted struct {
+	flash  bool
+	victim srcds.Client
+}
+
+func parseClientAssisted(clientLog srcds.ClientLogEntry) (clientAssisted, bool) {
+	tokens := clientAssistedRegex.FindStringSubmatch(clientLog.Message)
+
+	if len(tokens) != 3 {
+		return clientAssisted{}, false
+	}
+
+	victim, ok := srcds.ParseClient(tokens[2])
+	if !ok {
+		return clientAssisted{}, false
+	}
+
+	return clientAssisted{
+		flash:  tokens[1] == "flash-assisted",
+		victim: victim,
+	}, true
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+var clientAttackedRegex = regexp.MustCompile(`^\[-?\d+ -?\d+ -?\d+\] attacked (".+") \[-?\d+ -?\d+ -?\d+\] with "([\w-]*)" \(damage "(\d+)"\) \(damage_armor "(\d+)"\) \(health "(\d+)"\) \(armor "(\d+)"\) \(hitgroup "([\w ]+)"\)$`)
+
+// clientAttacked is sent whenever a client damages another client
+type clientAttacked struct {
+	victim      srcds.Client
+	weapon      string
+	damage      int
+	damageArmor int
+	health      int
+	armor       int
+	hitgroup    string
+}
+
+func parseClientAttacked(clientLog srcds.ClientLogEntry) (clientAttacked, bool) {
+	tokens := clientAttackedRegex.FindStringSubmatch(clientLog.Message)
+
+	if len(tokens) != 8 {
+		return clientAttacked{}, false
+	}
+
+	victim, ok := srcds.ParseClient(tokens[1])
+	if !ok {
+		return clientAttacked{}, false
+	}
+
+	r := clientAttacked{
+		victim:   victim,
+		weapon:   tokens[2],
+		hitgroup: tokens[7],
+	}
+	r.damage, _ = strconv.Atoi(tokens[3])
+	r.damageArmor, _ = strconv.Atoi(tokens[4])
+	r.health, _ = strconv.Atoi(tokens[5])
+	r.armor, _ = strconv.Atoi(tokens[6])
+
+	return r, true
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+var clientBlindedRegex = regexp.MustCompile(`^(?:was )?blinded (?:for ([\d.]+) )?by (".+?")(?: from flashbang entindex \d+)?$`)
+
+// clientBlinded is sent whenever a client is blinded by another client's flashbang
+type clientBlinded struct {
+	attacker srcds.Client
+	duration float64
+}
+
+func parseClientBlinded(clientLog srcds.ClientLogEntry) (clientBlinded, bool) {
+	tokens := clientBlindedRegex.FindStringSubmatch(clientLog.Message)
+
+	if len(tokens) != 3 {
+		return clientBlinded{}, false
+	}
+
+	attacker, ok := srcds.ParseClient(tokens[2])
+	if !ok {
+		return clientBlinded{}, false
+	}
+
+	r := clientBlinded{attacker: attacker}
+	r.duration, _ = strconv.ParseFloat(tokens[1], 64)
+
+	return r, true
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+var clientBombEventRegex = regexp.MustCompile(`^triggered "(Got_The_Bomb|Dropped_The_Bomb|Bomb_Begin_Plant|Planted_The_Bomb|Begin_Bomb_Defuse_With_Kit|Begin_Bomb_Defuse_Without_Kit|Defused_The_Bomb)"(?: at bombsite ([A-Za-z]))?$`)
+
+// bombAction represents an action taken by a client with the bomb
+type bombAction string
+
+const (
+	bombPickedUp       bombAction = "Got_The_Bomb"
+	bombDropped        bombAction = "Dropped_The_Bomb"
+	bombBeginPlant     bombAction = "Bomb_Begin_Plant"
+	bombPlanted        bombAction = "Planted_The_Bomb"
+	bombBeginDefuseKit bombAction = "Begin_Bomb_Defuse_With_Kit"
+	bombBeginDefuse    bombAction = "Begin_Bomb_Defuse_Without_Kit"
+	bombDefused        bombAction = "Defused_The_Bomb"
+)
+
+// clientBombEvent is sent whenever a client interacts with the bomb
+type clientBombEvent struct {
+	action bombAction
+	site   string
+}
+
+func parseClientBombEvent(clientLog srcds.ClientLogEntry) (clientBombEvent, bool) {
+	tokens := clientBombEventRegex.FindStringSubmatch(clientLog.Message)
+
+	if len(tokens) != 3 {
+		return clientBombEvent{}, false
+	}
+
+	return clientBombEvent{
+		action: bombAction(tokens[1]),
+		site:   strings.ToUpper(tokens[2]),
+	}, true
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+var clientKilledRegex = regexp.MustCompile(`^\[-?\d+ -?\d+ -?\d+\] killed (".+") \[-?\d+ -?\d+ -?\d+\] with "([\w-]*)"(?: \(([\w ]+)\))?$`)
+
+// clientKilled is sent whenever a client kills another client
+type clientKilled struct {
+	victim     srcds.Client
+	weapon     string
+	headshot   bool
+	penetrated bool
+}
+
+func parseClientKilled(clientLog srcds.ClientLogEntry) (clientKilled, bool) {
+	tokens := clientKilledRegex.FindStringSubmatch(clientLog.Message)
+
+	if len(tokens) != 4 {
+		return clientKilled{}, false
+	}
+
+	victim, ok := srcds.ParseClient(tokens[1])
+	if !ok {
+		return clientKilled{}, false
+	}
+
+	r := clientKilled{
+		victim: victim,
+		weapon: tokens[2],
+	}
+
+	for _, modifier := range strings.Fields(tokens[3]) {
+		switch modifier {
+		case "headshot":
+			r.headshot = true
+		case "penetrated":
+			r.penetrated = true
+		}
+	}
+
+	return r, true
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+var clientKilledByBombRegex = regexp.MustCompile(`^\[-?\d+ -?\d+ -?\d+\] was killed by the bomb\.$`)
+
+func parseClientKilledByBomb(clientLog srcds.ClientLogEntry) (ok bool) {
+	return clientKilledByBombRegex.MatchString(clientLog.Message)
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+var clientLeftBuyzoneRegex = regexp.MustCompile(`^left buyzone with \[ ?(.*?) ?\]$`)
+
+func parseClientLeftBuyzone(clientLog srcds.ClientLogEntry) (equipment []string, ok bool) {
+	tokens := clientLeftBuyzoneRegex.FindStringSubmatch(clientLog.Message)
+
+	if len(tokens) != 2 {
+		return nil, false
+	}
+
+	return strings.Fields(tokens[1]), true
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+var clientMoneyChangeRegex = regexp.MustCompile(`^money change (\d+)([+-])(\d+) = \$(\d+)(?: \(tracked\))?(?: \(purchase: ([\w]+)\))?$`)
+
+// clientMoneyChange is sent whenever a client's balance changes
+type clientMoneyChange struct {
+	previous int
+	delta    int
+	balance  int
+	purchase string
+}
+
+func parseClientMoneyChange(clientLog srcds.ClientLogEntry) (clientMoneyChange, bool) {
+	tokens := clientMoneyChangeRegex.FindStringSubmatch(clientLog.Message)
+
+	if len(tokens) != 6 {
+		return clientMoneyChange{}, false
+	}
+
+	r := clientMoneyChange{purchase: tokens[5]}
+	r.previous, _ = strconv.Atoi(tokens[1])
+	r.delta, _ = strconv.Atoi(tokens[3])
+	r.balance, _ = strconv.Atoi(tokens[4])
+
+	if tokens[2] == "-" {
+		r.delta = -r.delta
+	}
+
+	return r, true
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+var clientPurchasedRegex = regexp.MustCompile(`^purchased "([\w]+)"$`)
+
+func parseClientPurchased(clientLog srcds.ClientLogEntry) (item string, ok bool) {
+	tokens := clientPurchasedRegex.FindStringSubmatch(clientLog.Message)
+
+	if len(tokens) != 2 {
+		return "", false
+	}
+
+	return tokens[1], true
+}
+
 ///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 var playerSayRegex = regexp.MustCompile(`^(say_team|say) "(.+)"$`)
 
@@ -85,11 +383,50 @@ func parseClientSetAffiliation(clientLog srcds.ClientLogEntry) (clientSetAffilia
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
-var gameOverRegex = regexp.MustCompile(`^Game Over: (\w+)[ ]+(\w+) score (\d+):(\d+) after (\d+) min$`)
+var clientSuicideRegex = regexp.MustCompile(`^\[-?\d+ -?\d+ -?\d+\] committed suicide with "([\w-]*)"$`)
+
+func parseClientSuicide(clientLog srcds.ClientLogEntry) (weapon string, ok bool) {
+	tokens := clientSuicideRegex.FindStringSubmatch(clientLog.Message)
 
-// gameOver is (sometimes?) sent when the game is over
+	if len(tokens) != 2 {
+		return "", false
+	}
+
+	return tokens[1], true
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+var clientThrewRegex = regexp.MustCompile(`^threw (hegrenade|flashbang|smokegrenade|molotov|incgrenade|decoy) \[-?\d+ -?\d+ -?\d+\]`)
+
+// grenade represents a type of grenade
+type grenade string
+
+const (
+	grenadeHE         grenade = "hegrenade"
+	grenadeFlashbang  grenade = "flashbang"
+	grenadeSmoke      grenade = "smokegrenade"
+	grenadeMolotov    grenade = "molotov"
+	grenadeIncendiary grenade = "incgrenade"
+	grenadeDecoy      grenade = "decoy"
+)
+
+func parseClientThrew(clientLog srcds.ClientLogEntry) (g grenade, ok bool) {
+	tokens := clientThrewRegex.FindStringSubmatch(clientLog.Message)
+
+	if len(tokens) != 2 {
+		return "", false
+	}
+
+	return grenade(tokens[1]), true
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+var gameOverRegex = regexp.MustCompile(`^Game Over: (\w+) +(?:(\w+) +)?(\w+) score (\d+):(\d+) after (\d+) min$`)
+
+// gameOver is (sometimes?) sent when the game is over; the map group between the mode and the map is often empty
 type gameOver struct {
 	mode           string
+	mapGroup       string
 	mapName        string
 	score1         int
 	score2         int
@@ -99,31 +436,68 @@ type gameOver struct {
 func parseGameOver(le srcds.LogEntry) (m gameOver, ok bool) {
 	tokens := gameOverRegex.FindStringSubmatch(le.Message)
 
-	if len(tokens) != 6 {
+	if len(tokens) != 7 {
 		return gameOver{}, false
 	}
 
 	r := gameOver{}
-	r.minutesElapsed, _ = strconv.Atoi(tokens[5])
-	r.score2, _ = strconv.Atoi(tokens[4])
-	r.score1, _ = strconv.Atoi(tokens[3])
-	r.mapName = tokens[2]
+	r.minutesElapsed, _ = strconv.Atoi(tokens[6])
+	r.score2, _ = strconv.Atoi(tokens[5])
+	r.score1, _ = strconv.Atoi(tokens[4])
+	r.mapName = tokens[3]
+	r.mapGroup = tokens[2]
 	r.mode = tokens[1]
 
 	return r, true
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
-var loadingMapRegex = regexp.MustCompile(`^Loading map "(\w+)"$`)
+var mapNameRegex = regexp.MustCompile(`^[\w.][\w\-./]*$`)
+
+// isMapName determines if the name can be passed to SRCDS as a map (including workshop maps such as workshop/123/de_x)
+func isMapName(s string) bool {
+	return mapNameRegex.MatchString(s)
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+var matchStatusScoreRegex = regexp.MustCompile(`^MatchStatus: Score: (\d+):(\d+) on map "(\w+)" RoundsPlayed: (-?\d+)$`)
+
+// matchStatusScore is sent after every round with the CT and Terrorist scores; rounds played is -1 during warmup
+type matchStatusScore struct {
+	ctScore        int
+	terroristScore int
+	mapName        string
+	roundsPlayed   int
+}
+
+func parseMatchStatusScore(le srcds.LogEntry) (matchStatusScore, bool) {
+	tokens := matchStatusScoreRegex.FindStringSubmatch(le.Message)
+
+	if len(tokens) != 5 {
+		return matchStatusScore{}, false
+	}
+
+	r := matchStatusScore{}
+	r.roundsPlayed, _ = strconv.Atoi(tokens[4])
+	r.mapName = tokens[3]
+	r.terroristScore, _ = strconv.Atoi(tokens[2])
+	r.ctScore, _ = strconv.Atoi(tokens[1])
+
+	return r, true
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+var pauseCommandRegex = regexp.MustCompile(`(?:^|[\s"])(mp_pause_match|mp_unpause_match|timeout_ct_start|timeout_terrorist_start)(?:$|[\s";])`)
 
-func parseLoadingMap(le srcds.LogEntry) (mapName string, ok bool) {
-	tokens := loadingMapRegex.FindStringSubmatch(le.Message)
+// parsePauseCommand determines if a line contains a command pausing (or unpausing) the match
+func parsePauseCommand(line string) (paused bool, ok bool) {
+	tokens := pauseCommandRegex.FindStringSubmatch(line)
 
 	if len(tokens) != 2 {
-		return "", false
+		return false, false
 	}
 
-	return tokens[1], true
+	return tokens[1] != "mp_unpause_match", true
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -157,9 +531,9 @@ func parseTeamScored(le srcds.LogEntry) (teamScored, bool) {
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
-var teamSetNameRegex = regexp.MustCompile(`^Team playing "(.{1,})": (.{1,})$`)
+var teamSetNameRegex = regexp.MustCompile(`^(?:MatchStatus: )?Team playing "(.{1,})": (.{1,})$`)
 
-// teamSetName is sent whenever team information is set
+// teamSetName is sent whenever team information is set; the match status repeats it after every round
 type teamSetName struct {
 	affiliation affiliation
 	teamName    string
@@ -6,6 +6,41 @@ import (
 	"github.com/lacledeslan/sourceseer/pkg/srcds"
 )
 
+func Test_parseAccolade(t *testing.T) {
+	validCases := []struct {
+		msg      string
+		expected accolade
+	}{
+		{"ACCOLADE, FINAL: {mvp},\tAlpha<2>,\tVALUE: 9.000000,\tPOS: 1,\tSCORE: 60.000000", accolade{final: true, name: "mvp", username: "Alpha", position: 1, value: 9, score: 60}},
+		{"ACCOLADE, FINAL: {burndamage},\tThe <Dude><12>,\tVALUE: 154.000000,\tPOS: 3,\tSCORE: 15.500000", accolade{final: true, name: "burndamage", username: "The <Dude>", position: 3, value: 154, score: 15.5}},
+		{"ACCOLADE, ROUND: {5k},\tBravo<3>,\tVALUE: 1.000000,\tPOS: 1,\tSCORE: 40.000000", accolade{name: "5k", username: "Bravo", position: 1, value: 1, score: 40}},
+	}
+
+	t.Run("Valid Cases", func(t *testing.T) {
+		for _, test := range validCases {
+			if actual, ok := parseAccolade(srcds.LogEntry{Message: test.msg}); !ok {
+				t.Errorf("Message %q should have successfully parsed.", test.msg)
+			} else if actual != test.expected {
+				t.Errorf("Expected accolade %+v but got %+v from message %q.", test.expected, actual, test.msg)
+			}
+		}
+	})
+
+	invalidCases := []string{
+		``,
+		`ACCOLADE, FINAL: {mvp}, Alpha, VALUE: 9.000000, POS: 1, SCORE: 60.000000`,
+		`World triggered "Round_End"`,
+	}
+
+	t.Run("Invalid Cases", func(t *testing.T) {
+		for _, msg := range invalidCases {
+			if _, ok := parseAccolade(srcds.LogEntry{Message: msg}); ok {
+				t.Errorf("Message %q should NOT have successfully parsed.", msg)
+			}
+		}
+	})
+}
+
 func Test_parseAffiliation(t *testing.T) {
 	validCases := []struct {
 		input    string
@@ -45,6 +80,448 @@ func Test_parseAffiliation(t *testing.T) {
 	})
 }
 
+func Test_parseBackupFile(t *testing.T) {
+	validCases := []struct {
+		line           string
+		expectedName   string
+		expectedRounds int
+	}{
+		{`backup_round00.txt`, "backup_round00.txt", 0},
+		{`   backup_round05.txt  09/03 01:46   de_lltest   2:3`, "backup_round05.txt", 5},
+		{`lan_2019.09.03_de_lltest_round17.txt`, "lan_2019.09.03_de_lltest_round17.txt", 17},
+	}
+
+	t.Run("Valid Cases", func(t *testing.T) {
+		for _, test := range validCases {
+			if actual, ok := parseBackupFile(test.line); !ok {
+				t.Errorf("Line %q should have successfully parsed.", test.line)
+			} else {
+				if actual.name != test.expectedName {
+					t.Errorf("Expected name %q not %q from line %q.", test.expectedName, actual.name, test.line)
+				}
+
+				if actual.rounds != test.expectedRounds {
+					t.Errorf("Expected %d rounds not %d from line %q.", test.expectedRounds, actual.rounds, test.line)
+				}
+			}
+		}
+	})
+
+	invalidCases := []string{
+		``,
+		`backup_round.txt`,
+		`backup_round05.cfg`,
+		`Listing round backup files`,
+	}
+
+	t.Run("Invalid Cases", func(t *testing.T) {
+		for _, line := range invalidCases {
+			if _, ok := parseBackupFile(line); ok {
+				t.Errorf("Line %q should NOT have successfully parsed.", line)
+			}
+		}
+	})
+}
+
+func Test_parseBackupRestore(t *testing.T) {
+	validCases := []struct {
+		line           string
+		expectedName   string
+		expectedRounds int
+	}{
+		{`mp_backup_restore_load_file backup_round05.txt`, "backup_round05.txt", 5},
+		{`] mp_backup_restore_load_file "backup_round12.txt"`, "backup_round12.txt", 12},
+		{`rcon from "192.168.1.10:50123": command "mp_backup_restore_load_file backup_round03.txt"`, "backup_round03.txt", 3},
+	}
+
+	t.Run("Valid Cases", func(t *testing.T) {
+		for _, test := range validCases {
+			if actual, ok := parseBackupRestore(test.line); !ok {
+				t.Errorf("Line %q should have successfully parsed.", test.line)
+			} else {
+				if actual.name != test.expectedName {
+					t.Errorf("Expected name %q not %q from line %q.", test.expectedName, actual.name, test.line)
+				}
+
+				if actual.rounds != test.expectedRounds {
+					t.Errorf("Expected %d rounds not %d from line %q.", test.expectedRounds, actual.rounds, test.line)
+				}
+			}
+		}
+	})
+
+	invalidCases := []string{
+		``,
+		`backup_round05.txt`,
+		`mp_backup_restore_list_files`,
+		`mp_backup_restore_load_file`,
+		`mp_backup_restore_load_file backup.txt`,
+	}
+
+	t.Run("Invalid Cases", func(t *testing.T) {
+		for _, line := range invalidCases {
+			if _, ok := parseBackupRestore(line); ok {
+				t.Errorf("Line %q should NOT have successfully parsed.", line)
+			}
+		}
+	})
+}
+
+func Test_parseClientAssisted(t *testing.T) {
+	mockClient := srcds.Client{Username: "Hank", SteamID: "BOT", ServerSlot: 8, Affiliation: "TERRORIST"}
+
+	validCases := []struct {
+		msg            string
+		expectedFlash  bool
+		expectedVictim string
+	}{
+		{`assisted killing "Kevin<5><BOT><CT>"`, false, "Kevin"},
+		{`flash-assisted killing "Humorbot 5.0<3><STEAM_1:1:8675309><CT>"`, true, "Humorbot 5.0"},
+	}
+
+	t.Run("Valid Cases", func(t *testing.T) {
+		for _, test := range validCases {
+			if actual, ok := parseClientAssisted(srcds.ClientLogEntry{Client: mockClient, Message: test.msg}); !ok {
+				t.Errorf("Message %q should have successfully parsed.", test.msg)
+			} else {
+				if actual.flash != test.expectedFlash {
+					t.Errorf("Expected flash %t not %t from message %q.", test.expectedFlash, actual.flash, test.msg)
+				}
+
+				if actual.victim.Username != test.expectedVictim {
+					t.Errorf("Expected victim %q not %q from message %q.", test.expectedVictim, actual.victim.Username, test.msg)
+				}
+			}
+		}
+	})
+
+	invalidCases := []string{
+		``,
+		`assisted killing`,
+		`assisted killing "nobody"`,
+		`[0 0 0] killed "Kevin<5><BOT><CT>" [0 0 0] with "ak47"`,
+		`say "assisted killing"`,
+	}
+
+	t.Run("Invalid Cases", func(t *testing.T) {
+		for _, msg := range invalidCases {
+			if _, ok := parseClientAssisted(srcds.ClientLogEntry{Client: mockClient, Message: msg}); ok {
+				t.Errorf("Message %q should NOT have successfully parsed.", msg)
+			}
+		}
+	})
+}
+
+func Test_parseClientAttacked(t *testing.T) {
+	mockClient := srcds.Client{Username: "Wayne", SteamID: "BOT", ServerSlot: 5, Affiliation: "TERRORIST"}
+
+	validCases := []struct {
+		msg              string
+		expectedVictim   string
+		expectedWeapon   string
+		expectedDamage   int
+		expectedHealth   int
+		expectedHitgroup string
+	}{
+		{`[-1406 221 -60] attacked "BigBop Lil' Bop<14><STEAM_1:1:32971431><CT>" [-1406 221 -60] with "knife" (damage "30") (damage_armor "2") (health "57") (armor "95") (hitgroup "generic")`,
+			"BigBop Lil' Bop", "knife", 30, 57, "generic"},
+		{`[1 2 3] attacked "Kevin<5><BOT><CT>" [4 5 6] with "m4a1_silencer" (damage "112") (damage_armor "0") (health "0") (armor "0") (hitgroup "left leg")`,
+			"Kevin", "m4a1_silencer", 112, 0, "left leg"},
+	}
+
+	t.Run("Valid Cases", func(t *testing.T) {
+		for _, test := range validCases {
+			if actual, ok := parseClientAttacked(srcds.ClientLogEntry{Client: mockClient, Message: test.msg}); !ok {
+				t.Errorf("Message %q should have successfully parsed.", test.msg)
+			} else if actual.victim.Username != test.expectedVictim || actual.weapon != test.expectedWeapon || actual.damage != test.expectedDamage ||
+				actual.health != test.expectedHealth || actual.hitgroup != test.expectedHitgroup {
+				t.Errorf("Message %q parsed incorrectly as %+v.", test.msg, actual)
+			}
+		}
+	})
+
+	invalidCases := []string{
+		``,
+		`attacked "BigBop Lil' Bop<14><STEAM_1:1:32971431><CT>" [-1406 221 -60] with "knife" (damage "30") (damage_armor "2") (health "57") (armor "95") (hitgroup "generic")`,
+		`[1 2 3] killed "Kevin<5><BOT><CT>" [4 5 6] with "ak47"`,
+		`money change 16000-1000 = $15000 (tracked) (purchase: item_assaultsuit)`,
+	}
+
+	t.Run("Invalid Cases", func(t *testing.T) {
+		for _, msg := range invalidCases {
+			if _, ok := parseClientAttacked(srcds.ClientLogEntry{Client: mockClient, Message: msg}); ok {
+				t.Errorf("Message %q should NOT have successfully parsed.", msg)
+			}
+		}
+	})
+}
+
+func Test_parseClientBlinded(t *testing.T) {
+	mockClient := srcds.Client{Username: "Kevin", SteamID: "BOT", ServerSlot: 5, Affiliation: "CT"}
+
+	validCases := []struct {
+		msg              string
+		expectedAttacker string
+		expectedDuration float64
+	}{
+		{`blinded for 2.45 by "Reed<4><BOT><TERRORIST>" from flashbang entindex 128`, "Reed", 2.45},
+		{`blinded for 0.00 by "[LL] Loddy<3><STEAM_1:0:4665189><CT>" from flashbang entindex 275`, "[LL] Loddy", 0},
+		{`was blinded by "Reed<4><BOT><TERRORIST>"`, "Reed", 0},
+	}
+
+	t.Run("Valid Cases", func(t *testing.T) {
+		for _, test := range validCases {
+			if actual, ok := parseClientBlinded(srcds.ClientLogEntry{Client: mockClient, Message: test.msg}); !ok {
+				t.Errorf("Message %q should have successfully parsed.", test.msg)
+			} else {
+				if actual.attacker.Username != test.expectedAttacker {
+					t.Errorf("Expected attacker %q not %q from message %q.", test.expectedAttacker, actual.attacker.Username, test.msg)
+				}
+
+				if actual.duration != test.expectedDuration {
+					t.Errorf("Expected duration %f not %f from message %q.", test.expectedDuration, actual.duration, test.msg)
+				}
+			}
+		}
+	})
+
+	invalidCases := []string{
+		``,
+		`blinded for 2.45 by`,
+		`blinded for 2.45 by "nobody" from flashbang entindex 128`,
+		`threw flashbang [854 239 -187] flashbang entindex 128)`,
+	}
+
+	t.Run("Invalid Cases", func(t *testing.T) {
+		for _, msg := range invalidCases {
+			if _, ok := parseClientBlinded(srcds.ClientLogEntry{Client: mockClient, Message: msg}); ok {
+				t.Errorf("Message %q should NOT have successfully parsed.", msg)
+			}
+		}
+	})
+}
+
+func Test_parseClientBombEvent(t *testing.T) {
+	mockClient := srcds.Client{Username: "[LL] Loddy", SteamID: "STEAM_1:0:4665189", ServerSlot: 3, Affiliation: "TERRORIST"}
+
+	validCases := []struct {
+		msg            string
+		expectedAction bombAction
+		expectedSite   string
+	}{
+		{`triggered "Got_The_Bomb"`, bombPickedUp, ""},
+		{`triggered "Dropped_The_Bomb"`, bombDropped, ""},
+		{`triggered "Bomb_Begin_Plant" at bombsite A`, bombBeginPlant, "A"},
+		{`triggered "Planted_The_Bomb"`, bombPlanted, ""},
+		{`triggered "Planted_The_Bomb" at bombsite b`, bombPlanted, "B"},
+		{`triggered "Begin_Bomb_Defuse_With_Kit"`, bombBeginDefuseKit, ""},
+		{`triggered "Begin_Bomb_Defuse_Without_Kit"`, bombBeginDefuse, ""},
+		{`triggered "Defused_The_Bomb"`, bombDefused, ""},
+	}
+
+	t.Run("Valid Cases", func(t *testing.T) {
+		for _, test := range validCases {
+			if actual, ok := parseClientBombEvent(srcds.ClientLogEntry{Client: mockClient, Message: test.msg}); !ok {
+				t.Errorf("Message %q should have successfully parsed.", test.msg)
+			} else {
+				if actual.action != test.expectedAction {
+					t.Errorf("Expected action %q not %q from message %q.", test.expectedAction, actual.action, test.msg)
+				}
+
+				if actual.site != test.expectedSite {
+					t.Errorf("Expected site %q not %q from message %q.", test.expectedSite, actual.site, test.msg)
+				}
+			}
+		}
+	})
+
+	invalidCases := []string{
+		``,
+		`triggered "Got_The_Bomb" at bombsite`,
+		`triggered "Bomb_Exploded"`,
+		`triggered "clantag" (value "")`,
+		`say "Planted_The_Bomb"`,
+	}
+
+	t.Run("Invalid Cases", func(t *testing.T) {
+		for _, msg := range invalidCases {
+			if _, ok := parseClientBombEvent(srcds.ClientLogEntry{Client: mockClient, Message: msg}); ok {
+				t.Errorf("Message %q should NOT have successfully parsed.", msg)
+			}
+		}
+	})
+}
+
+func Test_parseClientKilled(t *testing.T) {
+	mockClient := srcds.Client{Username: "Wayne", SteamID: "BOT", ServerSlot: 5, Affiliation: "TERRORIST"}
+
+	validCases := []struct {
+		msg                string
+		expectedVictim     string
+		expectedWeapon     string
+		expectedHeadshot   bool
+		expectedPenetrated bool
+	}{
+		{`[-96 -1216 -167] killed "Kevin<5><BOT><CT>" [-303 -1466 -167] with "ak47"`, "Kevin", "ak47", false, false},
+		{`[-96 -1216 -167] killed "Kevin<5><BOT><CT>" [-303 -1466 -167] with "hkp2000" (headshot)`, "Kevin", "hkp2000", true, false},
+		{`[-96 -1216 -167] killed "Kevin<5><BOT><CT>" [-303 -1466 -167] with "awp" (penetrated)`, "Kevin", "awp", false, true},
+		{`[1 2 3] killed "[LL] Loddy<3><STEAM_1:0:4665189><CT>" [4 5 6] with "g3sg1" (headshot penetrated)`, "[LL] Loddy", "g3sg1", true, true},
+	}
+
+	t.Run("Valid Cases", func(t *testing.T) {
+		for _, test := range validCases {
+			if actual, ok := parseClientKilled(srcds.ClientLogEntry{Client: mockClient, Message: test.msg}); !ok {
+				t.Errorf("Message %q should have successfully parsed.", test.msg)
+			} else if actual.victim.Username != test.expectedVictim || actual.weapon != test.expectedWeapon ||
+				actual.headshot != test.expectedHeadshot || actual.penetrated != test.expectedPenetrated {
+				t.Errorf("Message %q parsed incorrectly as %+v.", test.msg, actual)
+			}
+		}
+	})
+
+	invalidCases := []string{
+		``,
+		`[1 2 3] killed other "chicken<199>" [4 5 6] with "ak47"`,
+		`[1 2 3] committed suicide with "world"`,
+		`[1 2 3] was killed by the bomb.`,
+		`assisted killing "Kevin<5><BOT><CT>"`,
+	}
+
+	t.Run("Invalid Cases", func(t *testing.T) {
+		for _, msg := range invalidCases {
+			if _, ok := parseClientKilled(srcds.ClientLogEntry{Client: mockClient, Message: msg}); ok {
+				t.Errorf("Message %q should NOT have successfully parsed.", msg)
+			}
+		}
+	})
+}
+
+func Test_parseClientKilledByBomb(t *testing.T) {
+	mockClient := srcds.Client{Username: "Kevin", SteamID: "BOT", ServerSlot: 5, Affiliation: "CT"}
+
+	if !parseClientKilledByBomb(srcds.ClientLogEntry{Client: mockClient, Message: `[-1201 -1104 -343] was killed by the bomb.`}) {
+		t.Error("Message should have successfully parsed.")
+	}
+
+	invalidCases := []string{
+		``,
+		`was killed by the bomb.`,
+		`[1 2 3] committed suicide with "world"`,
+	}
+
+	for _, msg := range invalidCases {
+		if parseClientKilledByBomb(srcds.ClientLogEntry{Client: mockClient, Message: msg}) {
+			t.Errorf("Message %q should NOT have successfully parsed.", msg)
+		}
+	}
+}
+
+func Test_parseClientLeftBuyzone(t *testing.T) {
+	mockClient := srcds.Client{Username: "Kevin", SteamID: "BOT", ServerSlot: 5, Affiliation: "CT"}
+
+	validCases := map[string][]string{
+		`left buyzone with [ weapon_knife weapon_hkp2000 ]`:                                {"weapon_knife", "weapon_hkp2000"},
+		`left buyzone with [ weapon_knife_t weapon_glock weapon_ak47 kevlar(100) helmet ]`: {"weapon_knife_t", "weapon_glock", "weapon_ak47", "kevlar(100)", "helmet"},
+		`left buyzone with [ ]`: {},
+	}
+
+	for msg, expected := range validCases {
+		actual, ok := parseClientLeftBuyzone(srcds.ClientLogEntry{Client: mockClient, Message: msg})
+		if !ok {
+			t.Errorf("Message %q should have successfully parsed.", msg)
+			continue
+		}
+
+		if len(actual) != len(expected) {
+			t.Errorf("Expected equipment %v not %v from message %q.", expected, actual, msg)
+			continue
+		}
+
+		for i := range expected {
+			if actual[i] != expected[i] {
+				t.Errorf("Expected equipment %v not %v from message %q.", expected, actual, msg)
+				break
+			}
+		}
+	}
+
+	invalidCases := []string{
+		``,
+		`left buyzone with`,
+		`purchased "m4a1"`,
+	}
+
+	for _, msg := range invalidCases {
+		if _, ok := parseClientLeftBuyzone(srcds.ClientLogEntry{Client: mockClient, Message: msg}); ok {
+			t.Errorf("Message %q should NOT have successfully parsed.", msg)
+		}
+	}
+}
+
+func Test_parseClientMoneyChange(t *testing.T) {
+	mockClient := srcds.Client{Username: "Kevin", SteamID: "BOT", ServerSlot: 5, Affiliation: "CT"}
+
+	validCases := []struct {
+		msg      string
+		expected clientMoneyChange
+	}{
+		{`money change 16000-1000 = $15000 (tracked) (purchase: item_assaultsuit)`, clientMoneyChange{previous: 16000, delta: -1000, balance: 15000, purchase: "item_assaultsuit"}},
+		{`money change 3400+3250 = $6650 (tracked)`, clientMoneyChange{previous: 3400, delta: 3250, balance: 6650}},
+		{`money change 800+200 = $1000 (tracked) (purchase: weapon_p250)`, clientMoneyChange{previous: 800, delta: 200, balance: 1000, purchase: "weapon_p250"}},
+		{`money change 800-300 = $500`, clientMoneyChange{previous: 800, delta: -300, balance: 500}},
+	}
+
+	for _, test := range validCases {
+		if actual, ok := parseClientMoneyChange(srcds.ClientLogEntry{Client: mockClient, Message: test.msg}); !ok {
+			t.Errorf("Message %q should have successfully parsed.", test.msg)
+		} else if actual != test.expected {
+			t.Errorf("Expected %+v not %+v from message %q.", test.expected, actual, test.msg)
+		}
+	}
+
+	invalidCases := []string{
+		``,
+		`money change`,
+		`money change 16000*1000 = $15000 (tracked)`,
+		`purchased "m4a1"`,
+	}
+
+	for _, msg := range invalidCases {
+		if _, ok := parseClientMoneyChange(srcds.ClientLogEntry{Client: mockClient, Message: msg}); ok {
+			t.Errorf("Message %q should NOT have successfully parsed.", msg)
+		}
+	}
+}
+
+func Test_parseClientPurchased(t *testing.T) {
+	mockClient := srcds.Client{Username: "Kevin", SteamID: "BOT", ServerSlot: 5, Affiliation: "CT"}
+
+	validCases := map[string]string{
+		`purchased "m4a1"`:             "m4a1",
+		`purchased "item_assaultsuit"`: "item_assaultsuit",
+	}
+
+	for msg, expected := range validCases {
+		if actual, ok := parseClientPurchased(srcds.ClientLogEntry{Client: mockClient, Message: msg}); !ok {
+			t.Errorf("Message %q should have successfully parsed.", msg)
+		} else if actual != expected {
+			t.Errorf("Expected item %q not %q from message %q.", expected, actual, msg)
+		}
+	}
+
+	invalidCases := []string{
+		``,
+		`purchased ""`,
+		`picked up "m4a1"`,
+	}
+
+	for _, msg := range invalidCases {
+		if _, ok := parseClientPurchased(srcds.ClientLogEntry{Client: mockClient, Message: msg}); ok {
+			t.Errorf("Message %q should NOT have successfully parsed.", msg)
+		}
+	}
+}
+
 func Test_parseClientSay(t *testing.T) {
 	mockClient := srcds.Client{
 		Username:    "AA",
@@ -167,6 +644,69 @@ func Test_parseClientSetAffiliation(t *testing.T) {
 	})
 }
 
+func Test_parseClientSuicide(t *testing.T) {
+	mockClient := srcds.Client{Username: "Kevin", SteamID: "BOT", ServerSlot: 5, Affiliation: "CT"}
+
+	validCases := map[string]string{
+		`[388 272 -2482] committed suicide with "world"`:     "world",
+		`[388 272 -2482] committed suicide with "hegrenade"`: "hegrenade",
+	}
+
+	for msg, expected := range validCases {
+		if actual, ok := parseClientSuicide(srcds.ClientLogEntry{Client: mockClient, Message: msg}); !ok {
+			t.Errorf("Message %q should have successfully parsed.", msg)
+		} else if actual != expected {
+			t.Errorf("Expected weapon %q not %q from message %q.", expected, actual, msg)
+		}
+	}
+
+	invalidCases := []string{
+		``,
+		`committed suicide with "world"`,
+		`[1 2 3] was killed by the bomb.`,
+	}
+
+	for _, msg := range invalidCases {
+		if _, ok := parseClientSuicide(srcds.ClientLogEntry{Client: mockClient, Message: msg}); ok {
+			t.Errorf("Message %q should NOT have successfully parsed.", msg)
+		}
+	}
+}
+
+func Test_parseClientThrew(t *testing.T) {
+	mockClient := srcds.Client{Username: "Reed", SteamID: "BOT", ServerSlot: 4, Affiliation: "TERRORIST"}
+
+	validCases := map[string]grenade{
+		`threw hegrenade [-1216 -1213 -137]`:                     grenadeHE,
+		`threw flashbang [854 239 -187] flashbang entindex 128)`: grenadeFlashbang,
+		`threw smokegrenade [-338 -1467 -109]`:                   grenadeSmoke,
+		`threw molotov [-1100 -670 -144]`:                        grenadeMolotov,
+		`threw incgrenade [-1100 -670 -144]`:                     grenadeIncendiary,
+		`threw decoy [-2134 1306 -71]`:                           grenadeDecoy,
+	}
+
+	for msg, expected := range validCases {
+		if actual, ok := parseClientThrew(srcds.ClientLogEntry{Client: mockClient, Message: msg}); !ok {
+			t.Errorf("Message %q should have successfully parsed.", msg)
+		} else if actual != expected {
+			t.Errorf("Expected grenade %q not %q from message %q.", expected, actual, msg)
+		}
+	}
+
+	invalidCases := []string{
+		``,
+		`threw hegrenade`,
+		`threw knife [1 2 3]`,
+		`picked up "hegrenade"`,
+	}
+
+	for _, msg := range invalidCases {
+		if _, ok := parseClientThrew(srcds.ClientLogEntry{Client: mockClient, Message: msg}); ok {
+			t.Errorf("Message %q should NOT have successfully parsed.", msg)
+		}
+	}
+}
+
 func Test_parseGameOver(t *testing.T) {
 	validCases := []struct {
 		msg            string
@@ -180,6 +720,7 @@ func Test_parseGameOver(t *testing.T) {
 		{"Game Over: competitive  de_nuke score 18:19 after 34 min", "competitive", "de_nuke", 18, 19, 34},
 		{"Game Over: competitive de_inferno score 3:16 after 38 min", "competitive", "de_inferno", 3, 16, 38},
 		{"Game Over: competitive  de_inferno score 3:16 after 38 min", "competitive", "de_inferno", 3, 16, 38},
+		{"Game Over: competitive mg_active de_ancient score 5:13 after 38 min", "competitive", "de_ancient", 5, 13, 38},
 	}
 
 	t.Run("Valid Cases", func(t *testing.T) {
@@ -232,50 +773,78 @@ func Test_parseGameOver(t *testing.T) {
 	})
 }
 
-func Test_parseLoadingMap(t *testing.T) {
+func Test_parseMatchStatusScore(t *testing.T) {
 	validCases := []struct {
-		msg         string
-		expectedMap string
+		msg      string
+		expected matchStatusScore
 	}{
-		{`Loading map "de_nuke"`, "de_nuke"},
-		{`Loading map "de_dust2"`, "de_dust2"},
+		{`MatchStatus: Score: 0:0 on map "de_ancient" RoundsPlayed: -1`, matchStatusScore{mapName: "de_ancient", roundsPlayed: -1}},
+		{`MatchStatus: Score: 5:12 on map "de_ancient" RoundsPlayed: 17`, matchStatusScore{ctScore: 5, terroristScore: 12, mapName: "de_ancient", roundsPlayed: 17}},
 	}
 
 	t.Run("Valid Cases", func(t *testing.T) {
 		for _, test := range validCases {
-			if actualMap, ok := parseLoadingMap(srcds.LogEntry{Message: test.msg}); !ok {
+			if actual, ok := parseMatchStatusScore(srcds.LogEntry{Message: test.msg}); !ok {
 				t.Errorf("Message %q should have successfully parsed.", test.msg)
-			} else if actualMap != test.expectedMap {
-				t.Errorf("Expected map %q but got map %q from message %q.", test.expectedMap, actualMap, test.msg)
+			} else if actual != test.expected {
+				t.Errorf("Expected match status %+v but got %+v from message %q.", test.expected, actual, test.msg)
 			}
 		}
 	})
 
 	invalidCases := []string{
 		``,
-		`        `,
-		`"mp_tournament" = "0"`,
-		`server_cvar: "mp_roundtime" "1.9167"`,
-		`rcon from "192.168.1.107:61968": command "echo HLSW: Test"`,
-		`"Console<0><Console><Console>" say "WarMod [BFG] WarmUp Config Loaded"`,
-		`"stiff 17<7><STEAM_1:2:66421616><CT>" triggered "clantag" (value "man from uncle")`,
-		`Team "CT" scored "0" with "5" players`,
-		`Team "TERRORIST" scored "0" with "5" players`,
-		`Team "CT" triggered "SFUI_Notice_Bomb_Defused" (CT "21") (T "7")`,
-		`Team "TERRORIST" triggered "SFUI_Notice_Bomb_Defused" (CT "21") (T "7")`,
-		`World triggered "Round_Start"`,
-		`World triggered "Round_End"`,
+		`MatchStatus: Team playing "CT": Laclede`,
+		`Score: 5:12 on map "de_ancient" RoundsPlayed: 17`,
 	}
 
 	t.Run("Invalid Cases", func(t *testing.T) {
 		for _, msg := range invalidCases {
-			if _, ok := parseLoadingMap(srcds.LogEntry{Message: msg}); ok {
+			if _, ok := parseMatchStatusScore(srcds.LogEntry{Message: msg}); ok {
 				t.Errorf("Message %q should NOT have successfully parsed.", msg)
 			}
 		}
 	})
 }
 
+func Test_parsePauseCommand(t *testing.T) {
+	validCases := []struct {
+		line           string
+		expectedPaused bool
+	}{
+		{`mp_pause_match`, true},
+		{`] mp_unpause_match`, false},
+		{`rcon from "192.168.1.107:61968": command "mp_pause_match"`, true},
+		{`rcon from "192.168.1.107:61968": command "timeout_terrorist_start"`, true},
+		{`timeout_ct_start`, true},
+	}
+
+	t.Run("Valid Cases", func(t *testing.T) {
+		for _, test := range validCases {
+			if actual, ok := parsePauseCommand(test.line); !ok {
+				t.Errorf("Line %q should have successfully parsed.", test.line)
+			} else if actual != test.expectedPaused {
+				t.Errorf("Expected paused %t not %t from line %q.", test.expectedPaused, actual, test.line)
+			}
+		}
+	})
+
+	invalidCases := []string{
+		``,
+		`mp_pause_match_timeout`,
+		`"sv_vote_issue_pause_match_spec_only" = "0"`,
+		`World triggered "Round_Start"`,
+	}
+
+	t.Run("Invalid Cases", func(t *testing.T) {
+		for _, line := range invalidCases {
+			if _, ok := parsePauseCommand(line); ok {
+				t.Errorf("Line %q should NOT have successfully parsed.", line)
+			}
+		}
+	})
+}
+
 func Test_parseStartingFreezePeriod(t *testing.T) {
 	t.Run("Valid Cases", func(t *testing.T) {
 		validCases := []string{
@@ -371,6 +940,7 @@ func Test_parseTeamSetName(t *testing.T) {
 	}{
 		{`Team playing "CT": New New Yorkers`, counterterrorist, "New New Yorkers"},
 		{`Team playing "TERRORIST": Thunder Cougar Falcon Birds`, terrorist, "Thunder Cougar Falcon Birds"},
+		{`MatchStatus: Team playing "CT": Laclede`, counterterrorist, "Laclede"},
 	}
 
 	t.Run("Valid Cases", func(t *testing.T) {
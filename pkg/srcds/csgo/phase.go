@@ -0,0 +1,216 @@
+package csgo
+
+import (
+	"strings"
+	"time"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+	"github.com/rs/zerolog/log"
+)
+
+// matchPhase is the current phase of play; exactly one primary phase is set, optionally combined with phase modifiers
+type matchPhase uint16
+
+const (
+	unknown matchPhase = 1 << iota
+	freezePeriod
+	halftime
+	knife
+	live
+	mapChanging
+	matchOver
+	preWarmup
+	roundLive
+	roundOver
+	warmup
+
+	// Phase modifiers
+	overtime
+	paused
+)
+
+// phaseModifiers may be combined with any primary phase
+const phaseModifiers = overtime | paused
+
+// primary returns the phase without any modifiers; the zero value is considered unknown
+func (p matchPhase) primary() matchPhase {
+	if r := p &^ phaseModifiers; r != 0 {
+		return r
+	}
+
+	return unknown
+}
+
+func (p matchPhase) String() string {
+	p = p.primary() | p&phaseModifiers
+	names := []string{}
+
+	for flag := unknown; flag <= paused; flag <<= 1 {
+		if p&flag != 0 {
+			names = append(names, flag.name())
+		}
+	}
+
+	return strings.Join(names, "+")
+}
+
+// name of a single phase flag
+func (p matchPhase) name() string {
+	switch p {
+	case unknown:
+		return "unknown"
+	case freezePeriod:
+		return "freeze_time"
+	case halftime:
+		return "halftime"
+	case knife:
+		return "knife"
+	case live:
+		return "live"
+	case mapChanging:
+		return "map_changing"
+	case matchOver:
+		return "match_over"
+	case preWarmup:
+		return "pre_warmup"
+	case roundLive:
+		return "round_live"
+	case roundOver:
+		return "round_over"
+	case warmup:
+		return "warmup"
+	case overtime:
+		return "overtime"
+	case paused:
+		return "paused"
+	default:
+		return "unknown"
+	}
+}
+
+// PhaseChanged is emitted whenever the match transitions from one phase to another
+type PhaseChanged struct {
+	srcds.EventTime
+	Match int
+	From  string
+	To    string
+}
+
+// Phase returns the current phase of play (e.g. "round_live" or "freeze_time+overtime+paused") and when it began
+func (o *Observer) Phase() (phase string, since time.Time) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	return o.game.phase.String(), o.game.phaseSince
+}
+
+// setPhase transitions to a new primary phase while preserving (and optionally adding to) the current phase modifiers
+func (g *gameInfo) setPhase(at time.Time, p matchPhase) {
+	g.transition(at, p|g.phase&phaseModifiers)
+}
+
+// setPhaseModifier adds (or removes) a phase modifier while preserving the current primary phase
+func (g *gameInfo) setPhaseModifier(at time.Time, m matchPhase, on bool) {
+	if on {
+		g.transition(at, g.phase.primary()|g.phase&phaseModifiers|m)
+		return
+	}
+
+	g.transition(at, g.phase.primary()|g.phase&phaseModifiers&^m)
+}
+
+// transition to the specified phase, emitting a PhaseChanged event if the phase is different
+func (g *gameInfo) transition(at time.Time, p matchPhase) {
+	if p.primary() == g.phase.primary() && p&phaseModifiers == g.phase&phaseModifiers {
+		return
+	}
+
+	from := g.phase
+	g.phase, g.phaseSince = p, at
+
+	log.Debug().Msgf("Match phase changed from %v to %v", from, p)
+
+	g.emit(PhaseChanged{
+		EventTime: srcds.EventTime{At: at},
+		Match:     len(g.matches),
+		From:      from.String(),
+		To:        p.String(),
+	})
+}
+
+// phaseGameCommencing marks the start of warmup as players join
+func (o *Observer) phaseGameCommencing(at time.Time) {
+	o.game.warmupMatchSeen = false
+	o.game.transition(at, warmup)
+}
+
+// phaseMatchStart transitions as a match starts; with warmup enabled the first match start is the warmup's
+func (o *Observer) phaseMatchStart(at time.Time) {
+	doWarmup := o.rule("mp_do_warmup")
+
+	if o.game.phase.primary() == warmup && !o.game.warmupMatchSeen && doWarmup != 0 {
+		o.game.warmupMatchSeen = true
+		return
+	}
+
+	o.game.warmupMatchSeen = false
+	o.game.transition(at, live)
+}
+
+// phaseFreezePeriod transitions as a freeze period starts; warmup has freeze periods of its own
+func (o *Observer) phaseFreezePeriod(at time.Time) {
+	switch o.game.phase.primary() {
+	case warmup, preWarmup, mapChanging, matchOver:
+		return
+	}
+
+	o.game.setPhase(at, freezePeriod)
+}
+
+// phaseRoundStart transitions as live play begins; an unpaused round is assumed
+func (o *Observer) phaseRoundStart(at time.Time) {
+	switch o.game.phase.primary() {
+	case warmup, preWarmup, mapChanging, matchOver:
+		return
+	}
+
+	next := roundLive
+	if o.isKnifeRound() {
+		next = knife
+	}
+
+	o.game.transition(at, next|o.game.phase&overtime)
+}
+
+// phaseRoundOver transitions after a round's winner has been recorded or a knife round has been discarded
+func (o *Observer) phaseRoundOver(at time.Time) {
+	if o.game.phase.primary() == knife {
+		o.game.setPhase(at, roundOver)
+		return
+	}
+
+	mpHalftime := o.rule("mp_halftime")
+	mpMaxrounds := o.rule("mp_maxrounds")
+	mpOvertimeMaxrounds := o.rule("mp_overtime_maxrounds")
+	lastCompletedRound := o.game.currentMatchLastCompletedRound()
+
+	next := roundOver
+	if calculateIsHalftime(mpHalftime, mpMaxrounds, mpOvertimeMaxrounds, lastCompletedRound) {
+		next = halftime
+	}
+
+	if over, _ := o.matchOutcome(); !over && o.gameMode().roundBased && mpMaxrounds > 0 && int(lastCompletedRound) >= mpMaxrounds {
+		// The next round will be played in overtime
+		next |= overtime
+	}
+
+	o.game.setPhase(at, next)
+}
+
+// isKnifeRound determines if the round is being played with knives only; knife configs strip the default pistols
+func (o *Observer) isKnifeRound() bool {
+	ctSecondary, ctSet := o.srcdsObserver.TryCvarAsString("mp_ct_default_secondary", "weapon_hkp2000")
+	tSecondary, tSet := o.srcdsObserver.TryCvarAsString("mp_t_default_secondary", "weapon_glock")
+
+	return ctSet && tSet && len(ctSecondary) == 0 && len(tSecondary) == 0
+}
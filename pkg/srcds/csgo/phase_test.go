@@ -0,0 +1,171 @@
+package csgo
+
+import (
+	"os"
+	"testing"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+)
+
+func Test_matchPhase_String(t *testing.T) {
+	testCases := map[matchPhase]string{
+		0:                                "unknown",
+		unknown:                          "unknown",
+		freezePeriod:                     "freeze_time",
+		paused:                           "unknown+paused",
+		freezePeriod | paused:            "freeze_time+paused",
+		roundLive | overtime:             "round_live+overtime",
+		freezePeriod | overtime | paused: "freeze_time+overtime+paused",
+	}
+
+	for phase, expected := range testCases {
+		if actual := phase.String(); actual != expected {
+			t.Errorf("Expected phase %q not %q.", expected, actual)
+		}
+	}
+}
+
+func observePhases(t *testing.T, sut *Observer, lines ...string) []string {
+	t.Helper()
+
+	phases := []string{}
+	sut.OnEvent(func(e srcds.Event) {
+		if p, ok := e.(PhaseChanged); ok {
+			phases = append(phases, p.To)
+		}
+	})
+
+	observeInto(t, sut, lines...)
+
+	return phases
+}
+
+func assertPhases(t *testing.T, expected, actual []string) {
+	t.Helper()
+
+	if len(actual) != len(expected) {
+		t.Fatalf("Expected phases %q not %q.", expected, actual)
+	}
+
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Fatalf("Expected phases %q not %q.", expected, actual)
+		}
+	}
+}
+
+func Test_Phases_warmupManual(t *testing.T) {
+	file, err := os.Open("./testdata/warmup_manual.log")
+	if err != nil {
+		t.Fatalf("Could not open log file: %v", err)
+	}
+	defer file.Close()
+
+	phases := []string{}
+
+	sut := NewObserver(1, 4, 3)
+	sut.OnEvent(func(e srcds.Event) {
+		if p, ok := e.(PhaseChanged); ok {
+			phases = append(phases, p.To)
+		}
+	})
+	sut.Read(file)
+	sut.Wait()
+
+	assertPhases(t, []string{
+		"map_changing", "pre_warmup", "warmup", "live",
+		"round_live", "round_over", "freeze_time",
+		"round_live", "halftime", "freeze_time",
+		"round_live", "round_over", "match_over",
+	}, phases)
+
+	if phase, since := sut.Phase(); phase != "match_over" || since.IsZero() {
+		t.Errorf("Expected the match to be over with a timestamp not %q since %v.", phase, since)
+	}
+}
+
+func Test_Phases_overtimeAndPause(t *testing.T) {
+	phases := observePhases(t, NewObserver(1, 2, 2),
+		`L 08/04/2019 - 20:00:00: Starting Freeze period`,
+		`L 08/04/2019 - 20:00:00: World triggered "Match_Start" on "de_lltest"`,
+		`L 08/04/2019 - 20:00:10: World triggered "Round_Start"`,
+		`L 08/04/2019 - 20:00:31: Team "CT" triggered "SFUI_Notice_CTs_Win" (CT "1") (T "0")`,
+		`L 08/04/2019 - 20:00:36: Starting Freeze period`,
+		`L 08/04/2019 - 20:00:40: rcon from "192.168.1.10:50123": command "mp_pause_match"`,
+		`L 08/04/2019 - 20:01:40: rcon from "192.168.1.10:50123": command "mp_unpause_match"`,
+		`L 08/04/2019 - 20:01:50: World triggered "Round_Start"`,
+		`L 08/04/2019 - 20:02:31: Team "CT" triggered "SFUI_Notice_CTs_Win" (CT "1") (T "1")`,
+		`L 08/04/2019 - 20:02:36: Starting Freeze period`,
+		`L 08/04/2019 - 20:02:50: World triggered "Round_Start"`,
+	)
+
+	assertPhases(t, []string{
+		"freeze_time", "live", "round_live", "halftime", "freeze_time", "freeze_time+paused", "freeze_time",
+		"round_live", "round_over+overtime", "freeze_time+overtime", "round_live+overtime",
+	}, phases)
+}
+
+func Test_Phases_knife(t *testing.T) {
+	phases := observePhases(t, NewObserver(1, 30, 6),
+		`L 08/04/2019 - 20:00:00: server_cvar: "mp_ct_default_secondary" ""`,
+		`L 08/04/2019 - 20:00:00: server_cvar: "mp_t_default_secondary" ""`,
+		`L 08/04/2019 - 20:00:00: Starting Freeze period`,
+		`L 08/04/2019 - 20:00:00: World triggered "Match_Start" on "de_lltest"`,
+		`L 08/04/2019 - 20:00:10: World triggered "Round_Start"`,
+		`L 08/04/2019 - 20:00:31: Team "CT" triggered "SFUI_Notice_CTs_Win" (CT "1") (T "0")`,
+	)
+
+	assertPhases(t, []string{"freeze_time", "live", "knife", "round_over"}, phases)
+}
+
+func Test_KnifeRound_notRecorded(t *testing.T) {
+	discrepancies := 0
+
+	sut := NewObserver(1, 30, 6)
+	sut.OnEvent(func(e srcds.Event) {
+		if _, ok := e.(ScoreDiscrepancy); ok {
+			discrepancies++
+		}
+	})
+
+	observeInto(t, sut,
+		`L 08/04/2019 - 20:00:00: server_cvar: "mp_ct_default_secondary" ""`,
+		`L 08/04/2019 - 20:00:00: server_cvar: "mp_t_default_secondary" ""`,
+		`L 08/04/2019 - 20:00:00: Starting Freeze period`,
+		`L 08/04/2019 - 20:00:00: World triggered "Match_Start" on "de_lltest"`,
+		`L 08/04/2019 - 20:00:01: "Alpha<3><STEAM_1:0:1001>" switched from team <Unassigned> to <CT>`,
+		`L 08/04/2019 - 20:00:01: "Xray<5><STEAM_1:0:2001>" switched from team <Unassigned> to <TERRORIST>`,
+		`L 08/04/2019 - 20:00:10: World triggered "Round_Start"`,
+		`L 08/04/2019 - 20:00:20: `+statsAlpha+` [0 0 0] killed `+statsXray+` [0 0 0] with "knife"`,
+		`L 08/04/2019 - 20:00:31: Team "CT" triggered "SFUI_Notice_CTs_Win" (CT "1") (T "0")`,
+		`L 08/04/2019 - 20:00:31: Team "CT" scored "1" with "1" players`,
+		`L 08/04/2019 - 20:00:31: Team "TERRORIST" scored "0" with "1" players`,
+		`L 08/04/2019 - 20:00:31: MatchStatus: Score: 1:0 on map "de_lltest" RoundsPlayed: 1`,
+		// The knife config is replaced by the live config and the game restarted
+		`L 08/04/2019 - 20:00:40: server_cvar: "mp_ct_default_secondary" "weapon_hkp2000"`,
+		`L 08/04/2019 - 20:00:40: server_cvar: "mp_t_default_secondary" "weapon_glock"`,
+		`L 08/04/2019 - 20:00:45: Starting Freeze period`,
+		`L 08/04/2019 - 20:00:45: World triggered "Match_Start" on "de_lltest"`,
+		`L 08/04/2019 - 20:01:00: World triggered "Round_Start"`,
+		`L 08/04/2019 - 20:01:31: Team "TERRORIST" triggered "SFUI_Notice_Terrorists_Win" (CT "0") (T "1")`,
+		`L 08/04/2019 - 20:01:31: Team "CT" scored "0" with "1" players`,
+		`L 08/04/2019 - 20:01:31: Team "TERRORIST" scored "1" with "1" players`,
+		`L 08/04/2019 - 20:01:31: MatchStatus: Score: 0:1 on map "de_lltest" RoundsPlayed: 1`,
+	)
+
+	if rounds := sut.game.currentMatchLastCompletedRound(); rounds != 1 {
+		t.Errorf("Expected 1 completed round not %d.", rounds)
+	}
+
+	if sut.statistics.roundsCompleted != 1 {
+		t.Errorf("Expected 1 round to be counted as completed not %d.", sut.statistics.roundsCompleted)
+	}
+
+	if team1, team2 := sut.game.scoresCurrentMatch(); team1 != 0 || team2 != 1 {
+		t.Errorf("Expected a score of 0:1 not %d:%d.", team1, team2)
+	}
+
+	if discrepancies != 0 {
+		t.Errorf("Expected no score discrepancies not %d.", discrepancies)
+	}
+}
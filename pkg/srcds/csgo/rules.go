@@ -17,6 +17,7 @@ const (
 	defaultMpDoWarmupPeriod    int = 1
 	defaultMpHalftime          int = 1
 	defaultMpMaxrounds         int = 30
+	defaultMpMatchCanClinch    int = 1
 	defaultMpMatchRestartDelay int = 15
 	defaultMpOvertimeEnabled   int = 1
 	defaultMpOvertimeMaxrounds int = 6
@@ -25,6 +26,29 @@ const (
 	defaultSvPausable          int = 0
 )
 
+func calculateIsHalftime(mpHalftime, mpMaxRounds, mpOvertimeMaxRounds int, lastCompletedRound lastInt) bool {
+	if lastCompletedRound < 1 {
+		return false
+	}
+
+	return calculateSidesAreCurrentlySwitched(mpHalftime, mpMaxRounds, mpOvertimeMaxRounds, lastCompletedRound) !=
+		calculateSidesAreCurrentlySwitched(mpHalftime, mpMaxRounds, mpOvertimeMaxRounds, lastCompletedRound-1)
+}
+
+func calculateIsPistolRound(mpHalftime, mpMaxRounds int, lastCompletedRound lastInt) bool {
+	if mpHalftime < 0 || mpHalftime > 1 {
+		mpHalftime = defaultMpHalftime
+	}
+
+	if mpMaxRounds < 1 {
+		mpMaxRounds = defaultMpMaxrounds
+	}
+
+	currentRound := int(lastCompletedRound) + 1
+
+	return currentRound == 1 || (mpHalftime == 1 && mpMaxRounds > 1 && currentRound == mpMaxRounds/2+1)
+}
+
 func calculateLastRoundWinThreshold(mpMaxRounds, mpOvertimeMaxRounds int, lastCompletedRound lastInt) lastInt {
 	if mpMaxRounds < 1 {
 		mpMaxRounds = defaultMpMaxrounds
@@ -53,6 +77,55 @@ func calculateLastRoundWinThreshold(mpMaxRounds, mpOvertimeMaxRounds int, lastCo
 	return lastInt(mpMaxRounds/2 + 1)
 }
 
+// calculateMatchOutcome determines if the match is over after the last completed round and which team won it; a match
+// that is over without a winner is a draw
+func calculateMatchOutcome(mpMaxRounds, mpOvertimeMaxRounds, mpOvertimeEnable, mpMatchCanClinch int, mpTeam1Wins, mpTeam2Wins lastInt) (over bool, winner team) {
+	if mpMaxRounds < 1 {
+		mpMaxRounds = defaultMpMaxrounds
+	}
+
+	lastCompletedRound := mpTeam1Wins + mpTeam2Wins
+
+	if int(lastCompletedRound) > mpMaxRounds {
+		winThreshold := calculateLastRoundWinThreshold(mpMaxRounds, mpOvertimeMaxRounds, lastCompletedRound)
+
+		switch {
+		case mpTeam1Wins >= winThreshold:
+			return true, mpTeam1
+		case mpTeam2Wins >= winThreshold:
+			return true, mpTeam2
+		}
+
+		return false, ""
+	}
+
+	if mpMatchCanClinch != 0 {
+		winThreshold := lastInt(mpMaxRounds/2 + 1)
+
+		switch {
+		case mpTeam1Wins >= winThreshold:
+			return true, mpTeam1
+		case mpTeam2Wins >= winThreshold:
+			return true, mpTeam2
+		}
+	}
+
+	if int(lastCompletedRound) < mpMaxRounds {
+		return false, ""
+	}
+
+	switch {
+	case mpTeam1Wins > mpTeam2Wins:
+		return true, mpTeam1
+	case mpTeam2Wins > mpTeam1Wins:
+		return true, mpTeam2
+	case mpOvertimeEnable == 0:
+		return true, ""
+	}
+
+	return false, ""
+}
+
 func calcOvertimePeriodNumber(mpMaxRounds, mpOvertimeMaxRounds int, lastCompletedRound lastInt) int {
 	if mpMaxRounds < 1 {
 		mpMaxRounds = defaultMpMaxrounds
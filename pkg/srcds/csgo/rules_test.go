@@ -2,6 +2,116 @@ package csgo
 
 import "testing"
 
+func Test_calculateIsHalftime(t *testing.T) {
+	testCases := map[string]struct {
+		mpHalftime          int
+		mpMaxRounds         int
+		mpOvertimeMaxRounds int
+		halftime            []lastInt
+		notHalftime         []lastInt
+	}{
+		"Default Settings":  {mpHalftime: 1, mpMaxRounds: 30, mpOvertimeMaxRounds: 6, halftime: []lastInt{15, 33, 39}, notHalftime: []lastInt{0, 1, 14, 16, 30, 36}},
+		"Halftime Disabled": {mpHalftime: 0, mpMaxRounds: 30, mpOvertimeMaxRounds: 6, notHalftime: []lastInt{0, 15, 30, 33}},
+		"Hasty Settings":    {mpHalftime: 1, mpMaxRounds: 4, mpOvertimeMaxRounds: 3, halftime: []lastInt{2}, notHalftime: []lastInt{0, 1, 3, 4}},
+		"MR12 Settings":     {mpHalftime: 1, mpMaxRounds: 24, mpOvertimeMaxRounds: 6, halftime: []lastInt{12, 27, 33}, notHalftime: []lastInt{0, 11, 13, 24, 30}},
+	}
+
+	for name, test := range testCases {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			for _, lastCompletedRound := range test.halftime {
+				if !calculateIsHalftime(test.mpHalftime, test.mpMaxRounds, test.mpOvertimeMaxRounds, lastCompletedRound) {
+					t.Errorf("With `mp_halftime` = `%d` and `mp_maxrounds` = `%d` halftime should follow round %d.", test.mpHalftime, test.mpMaxRounds, lastCompletedRound)
+				}
+			}
+
+			for _, lastCompletedRound := range test.notHalftime {
+				if calculateIsHalftime(test.mpHalftime, test.mpMaxRounds, test.mpOvertimeMaxRounds, lastCompletedRound) {
+					t.Errorf("With `mp_halftime` = `%d` and `mp_maxrounds` = `%d` halftime should NOT follow round %d.", test.mpHalftime, test.mpMaxRounds, lastCompletedRound)
+				}
+			}
+		})
+	}
+}
+
+func Test_calculateIsPistolRound(t *testing.T) {
+	testCases := map[string]struct {
+		mpHalftime  int
+		mpMaxRounds int
+		pistol      []lastInt
+		notPistol   []lastInt
+	}{
+		"Default Settings":    {mpHalftime: 1, mpMaxRounds: 30, pistol: []lastInt{0, 15}, notPistol: []lastInt{1, 14, 16, 29, 30, 33}},
+		"Halftime Disabled":   {mpHalftime: 0, mpMaxRounds: 30, pistol: []lastInt{0}, notPistol: []lastInt{1, 15, 30}},
+		"Hasty Settings":      {mpHalftime: 1, mpMaxRounds: 4, pistol: []lastInt{0, 2}, notPistol: []lastInt{1, 3, 4}},
+		"Invalid cvar values": {mpHalftime: -99, mpMaxRounds: -99, pistol: []lastInt{0, 15}, notPistol: []lastInt{7, 16}},
+	}
+
+	for name, test := range testCases {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			for _, lastCompletedRound := range test.pistol {
+				if !calculateIsPistolRound(test.mpHalftime, test.mpMaxRounds, lastCompletedRound) {
+					t.Errorf("With `mp_halftime` = `%d` and `mp_maxrounds` = `%d` the round after %d should be a pistol round.", test.mpHalftime, test.mpMaxRounds, lastCompletedRound)
+				}
+			}
+
+			for _, lastCompletedRound := range test.notPistol {
+				if calculateIsPistolRound(test.mpHalftime, test.mpMaxRounds, lastCompletedRound) {
+					t.Errorf("With `mp_halftime` = `%d` and `mp_maxrounds` = `%d` the round after %d should NOT be a pistol round.", test.mpHalftime, test.mpMaxRounds, lastCompletedRound)
+				}
+			}
+		})
+	}
+}
+
+func Test_calculateMatchOutcome(t *testing.T) {
+	testCases := map[string]struct {
+		mpMaxRounds         int
+		mpOvertimeMaxRounds int
+		mpOvertimeEnable    int
+		mpMatchCanClinch    int
+		mpTeam1Wins         lastInt
+		mpTeam2Wins         lastInt
+		expectedOver        bool
+		expectedWinner      team
+	}{
+		"First round":                 {30, 6, 1, 1, 1, 0, false, ""},
+		"Clinched":                    {30, 6, 1, 1, 16, 3, true, mpTeam1},
+		"Clinched by team 2":          {30, 6, 1, 1, 14, 16, true, mpTeam2},
+		"Not yet clinched":            {30, 6, 1, 1, 15, 14, false, ""},
+		"Tied heads to overtime":      {30, 6, 1, 1, 15, 15, false, ""},
+		"Tied without overtime":       {30, 6, 0, 1, 15, 15, true, ""},
+		"Overtime won":                {30, 6, 1, 1, 19, 16, true, mpTeam1},
+		"Overtime tied":               {30, 6, 1, 1, 18, 18, false, ""},
+		"Second overtime won":         {30, 6, 1, 1, 20, 22, true, mpTeam2},
+		"Can't clinch; still playing": {30, 6, 1, 0, 16, 3, false, ""},
+		"Can't clinch; all played":    {30, 6, 1, 0, 20, 10, true, mpTeam1},
+		"Can't clinch; tied":          {30, 6, 0, 0, 15, 15, true, ""},
+		"Can't clinch; overtime":      {30, 6, 1, 0, 15, 15, false, ""},
+		"Invalid cvar values":         {-1, -1, 1, 1, 16, 0, true, mpTeam1},
+		"MR12 clinched":               {24, 6, 1, 1, 13, 5, true, mpTeam1},
+		"MR12 not yet clinched":       {24, 6, 1, 1, 12, 5, false, ""},
+		"MR12 tied without overtime":  {24, 6, 0, 1, 12, 12, true, ""},
+		"MR12 overtime won":           {24, 6, 1, 1, 16, 14, true, mpTeam1},
+	}
+
+	for name, test := range testCases {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			over, winner := calculateMatchOutcome(test.mpMaxRounds, test.mpOvertimeMaxRounds, test.mpOvertimeEnable, test.mpMatchCanClinch, test.mpTeam1Wins, test.mpTeam2Wins)
+
+			if over != test.expectedOver {
+				t.Errorf("Expected the match to be over to be %t with a score of %d:%d.", test.expectedOver, test.mpTeam1Wins, test.mpTeam2Wins)
+			}
+
+			if winner != test.expectedWinner {
+				t.Errorf("Expected winner %q not %q with a score of %d:%d.", test.expectedWinner, winner, test.mpTeam1Wins, test.mpTeam2Wins)
+			}
+		})
+	}
+}
+
 func Test_calculateLastRoundWinThreshold(t *testing.T) {
 	testCases := map[string]struct {
 		mpMaxRounds         int
@@ -77,6 +187,7 @@ func Test_calculateLastRoundWinThreshold(t *testing.T) {
 	}
 
 	for name, test := range testCases {
+		test := test
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 			for expected, lastCompletedRounds := range test.scenarios {
@@ -138,6 +249,7 @@ func Test_calcOvertimePeriodNumber(t *testing.T) {
 	}
 
 	for name, test := range testCases {
+		test := test
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 			for expectedOTNumber, lastCompletedRounds := range test.overtimePeriodRounds {
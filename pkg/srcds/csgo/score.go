@@ -0,0 +1,162 @@
+package csgo
+
+import (
+	"time"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	scoreSourceRoundEnd    = "round_end"
+	scoreSourceTeamScored  = "team_scored"
+	scoreSourceMatchStatus = "match_status"
+	scoreSourceGameOver    = "game_over"
+)
+
+// ScoreDiscrepancy is emitted when the scores computed by the observer disagree with the scores reported by the server
+type ScoreDiscrepancy struct {
+	srcds.EventTime
+	Match         int
+	Source        string // round_end, team_scored, match_status, or game_over
+	ComputedTeam1 int
+	ComputedTeam2 int
+	ReportedTeam1 int
+	ReportedTeam2 int
+}
+
+// reconcileScores corrects the current match's round history to agree with the scores reported by the server. Rounds
+// that were never observed are assumed to be the oldest rounds (late attach) so placeholders are added to (or removed
+// from) the start of the history; the round currently being played is left untouched.
+func (g *gameInfo) reconcileScores(at time.Time, source string, team1, team2 lastInt) {
+	if team1 < 0 || team2 < 0 {
+		return
+	}
+
+	computed1, computed2 := g.scoresCurrentMatch()
+	if computed1 == team1 && computed2 == team2 {
+		return
+	}
+
+	if len(g.matches) == 0 {
+		g.matches = []matchInfo{matchInfo{}}
+	}
+
+	matchIndex := len(g.matches) - 1
+
+	log.Warn().Int("match", matchIndex+1).Int("team1_score", int(computed1)).Int("team2_score", int(computed2)).
+		Int("reported_team1_score", int(team1)).Int("reported_team2_score", int(team2)).
+		Msgf("Computed scores disagree with the server (%v); correcting round history", source)
+
+	g.emit(ScoreDiscrepancy{
+		EventTime:     srcds.EventTime{At: at},
+		Match:         matchIndex + 1,
+		Source:        source,
+		ComputedTeam1: int(computed1),
+		ComputedTeam2: int(computed2),
+		ReportedTeam1: int(team1),
+		ReportedTeam2: int(team2),
+	})
+
+	g.matches[matchIndex].adjustRoundsWon(mpTeam1, int(team1-computed1))
+	g.matches[matchIndex].adjustRoundsWon(mpTeam2, int(team2-computed2))
+}
+
+// adjustRoundsWon adds placeholder rounds for (or removes the oldest rounds won by) the specified team
+func (m *matchInfo) adjustRoundsWon(t team, delta int) {
+	for ; delta > 0; delta-- {
+		m.rounds = append([]roundInfo{{winningTeam: t, reconstructed: true}}, m.rounds...)
+	}
+
+	for i := 0; delta < 0 && i < len(m.rounds); {
+		if m.rounds[i].winningTeam == t {
+			m.rounds = append(m.rounds[:i], m.rounds[i+1:]...)
+			delta++
+			continue
+		}
+
+		i++
+	}
+}
+
+// sidesSwitchedDuring determines if mp_team1 was playing as the terrorists during the specified round number
+func (o *Observer) sidesSwitchedDuring(roundNumber int) bool {
+	mpHalftime := o.rule("mp_halftime")
+	mpMaxrounds := o.rule("mp_maxrounds")
+	mpOvertimeMaxrounds := o.rule("mp_overtime_maxrounds")
+
+	return calculateSidesAreCurrentlySwitched(mpHalftime, mpMaxrounds, mpOvertimeMaxrounds, lastInt(roundNumber-1))
+}
+
+// teamScores converts CT/T scores into mp_team1/mp_team2 scores for the specified round number
+func (o *Observer) teamScores(roundNumber, ctScore, terroristScore int) (team1, team2 lastInt) {
+	if o.sidesSwitchedDuring(roundNumber) {
+		return lastInt(terroristScore), lastInt(ctScore)
+	}
+
+	return lastInt(ctScore), lastInt(terroristScore)
+}
+
+// reconcileGameOver cross-checks the final scores reported when the match ends
+func (o *Observer) reconcileGameOver(at time.Time, m gameOver) {
+	team1, team2 := o.teamScores(m.score1+m.score2, m.score1, m.score2)
+	o.game.reconcileScores(at, scoreSourceGameOver, team1, team2)
+
+	if len(o.game.matches) > 0 {
+		o.game.matches[len(o.game.matches)-1].ended = at
+	}
+}
+
+// reconcileRoundEnd cross-checks the scores reported when a team wins a round, before the round is recorded
+func (o *Observer) reconcileRoundEnd(at time.Time, m teamTriggered) {
+	roundNumber := m.ctScore + m.terroristScore
+	if roundNumber < 1 {
+		return
+	}
+
+	team1, team2 := o.teamScores(roundNumber, m.ctScore, m.terroristScore)
+
+	// The round being reported has yet to be recorded
+	winner := mpTeam1
+	if (m.affiliation == counterterrorist) == o.sidesSwitchedDuring(roundNumber) {
+		winner = mpTeam2
+	}
+
+	if winner == mpTeam1 {
+		team1--
+	} else {
+		team2--
+	}
+
+	o.game.reconcileScores(at, scoreSourceRoundEnd, team1, team2)
+}
+
+// reconcileTeamScored cross-checks a single team's score reported after a round has been recorded; scores reported for
+// a knife round are ignored
+func (o *Observer) reconcileTeamScored(at time.Time, m teamScored) {
+	if len(o.game.matches) == 0 || o.game.knifeRoundOver {
+		return
+	}
+
+	team1, team2 := o.game.scoresCurrentMatch()
+	roundNumber := int(o.game.currentMatchLastCompletedRound())
+
+	if (m.affiliation == counterterrorist) != o.sidesSwitchedDuring(roundNumber) {
+		team1 = lastInt(m.Score)
+	} else {
+		team2 = lastInt(m.Score)
+	}
+
+	o.game.reconcileScores(at, scoreSourceTeamScored, team1, team2)
+}
+
+// reconcileMatchStatus cross-checks the scores reported in the match status after every round; warmup is reported as -1
+// rounds played and knife rounds are ignored
+func (o *Observer) reconcileMatchStatus(at time.Time, m matchStatusScore) {
+	if m.roundsPlayed < 1 || o.game.knifeRoundOver {
+		return
+	}
+
+	team1, team2 := o.teamScores(m.roundsPlayed, m.ctScore, m.terroristScore)
+	o.game.reconcileScores(at, scoreSourceMatchStatus, team1, team2)
+}
@@ -0,0 +1,146 @@
+package csgo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+)
+
+func Test_gameInfo_reconcileScores(t *testing.T) {
+	tests := map[string]struct {
+		rounds          []team
+		team1, team2    lastInt
+		expectedRounds  []team
+		expectedChanged bool
+	}{
+		"agrees":        {[]team{mpTeam1, mpTeam2}, 1, 1, []team{mpTeam1, mpTeam2}, false},
+		"missed rounds": {[]team{mpTeam1}, 2, 1, []team{mpTeam2, mpTeam1, mpTeam1}, true},
+		"extra rounds":  {[]team{mpTeam2, mpTeam1, mpTeam2, mpTeam2}, 1, 1, []team{mpTeam1, mpTeam2}, true},
+		"no history":    {[]team{}, 0, 2, []team{mpTeam2, mpTeam2}, true},
+		"negative":      {[]team{mpTeam1}, -1, 0, []team{mpTeam1}, false},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			sut := gameInfo{}
+			if len(test.rounds) > 0 {
+				sut.matches = []matchInfo{{}}
+				for _, winner := range test.rounds {
+					sut.matches[0].rounds = append(sut.matches[0].rounds, roundInfo{winningTeam: winner})
+				}
+			}
+
+			changed := false
+			sut.events.OnEvent(func(srcds.Event) { changed = true })
+			sut.reconcileScores(time.Time{}, scoreSourceRoundEnd, test.team1, test.team2)
+			sut.events.Dispatch()
+
+			if changed != test.expectedChanged {
+				t.Errorf("Expected a discrepancy to be reported to be %v.", test.expectedChanged)
+			}
+
+			actual := []team{}
+			if len(sut.matches) > 0 {
+				for _, r := range sut.matches[0].rounds {
+					actual = append(actual, r.winningTeam)
+				}
+			}
+
+			if len(actual) != len(test.expectedRounds) {
+				t.Fatalf("Expected round winners %v not %v.", test.expectedRounds, actual)
+			}
+
+			for i := range actual {
+				if actual[i] != test.expectedRounds[i] {
+					t.Fatalf("Expected round winners %v not %v.", test.expectedRounds, actual)
+				}
+			}
+		})
+	}
+}
+
+func Test_ScoreReconciliation(t *testing.T) {
+	t.Run("late attach", func(t *testing.T) {
+		events := []ScoreDiscrepancy{}
+
+		sut := NewObserver(1, 4, 3)
+		sut.OnEvent(func(e srcds.Event) {
+			if d, ok := e.(ScoreDiscrepancy); ok {
+				events = append(events, d)
+			}
+		})
+
+		observeInto(t, sut,
+			// Rounds 1 and 2 were never observed; round 3 is after halftime so mp_team1 is playing as the terrorists
+			`L 08/04/2019 - 20:00:10: World triggered "Round_Start"`,
+			`L 08/04/2019 - 20:00:31: Team "TERRORIST" triggered "SFUI_Notice_Terrorists_Win" (CT "1") (T "2")`,
+			`L 08/04/2019 - 20:00:31: Team "CT" scored "1" with "1" players`,
+			`L 08/04/2019 - 20:00:31: Team "TERRORIST" scored "2" with "1" players`,
+		)
+
+		if len(events) != 1 {
+			t.Fatalf("Expected 1 discrepancy to have been reported not %d.", len(events))
+		}
+
+		e := events[0]
+		if e.Source != scoreSourceRoundEnd || e.ComputedTeam1 != 0 || e.ComputedTeam2 != 0 || e.ReportedTeam1 != 1 || e.ReportedTeam2 != 1 {
+			t.Errorf("Unexpected discrepancy %+v.", e)
+		}
+
+		match, _ := sut.CurrentMatch()
+		if len(match.Rounds) != 3 {
+			t.Fatalf("Expected 3 rounds not %d.", len(match.Rounds))
+		}
+
+		if !match.Rounds[0].Reconstructed || !match.Rounds[1].Reconstructed || match.Rounds[2].Reconstructed {
+			t.Error("Expected only the first two rounds to have been reconstructed.")
+		}
+
+		if team1, team2 := sut.game.scoresCurrentMatch(); team1 != 2 || team2 != 1 {
+			t.Errorf("Expected a score of 2:1 not %d:%d.", team1, team2)
+		}
+	})
+
+	t.Run("game over", func(t *testing.T) {
+		events := []ScoreDiscrepancy{}
+
+		sut := NewObserver(1, 4, 3)
+		sut.OnEvent(func(e srcds.Event) {
+			if d, ok := e.(ScoreDiscrepancy); ok {
+				events = append(events, d)
+			}
+		})
+
+		observeInto(t, sut,
+			`L 08/04/2019 - 20:00:00: World triggered "Match_Start" on "de_lltest"`,
+			`L 08/04/2019 - 20:00:10: World triggered "Round_Start"`,
+			`L 08/04/2019 - 20:00:31: Team "CT" triggered "SFUI_Notice_CTs_Win" (CT "1") (T "0")`,
+			`L 08/04/2019 - 20:01:10: World triggered "Round_Start"`,
+			`L 08/04/2019 - 20:01:31: Team "CT" triggered "SFUI_Notice_CTs_Win" (CT "2") (T "0")`,
+			`L 08/04/2019 - 20:02:10: World triggered "Round_Start"`,
+			`L 08/04/2019 - 20:02:31: Team "TERRORIST" triggered "SFUI_Notice_Terrorists_Win" (CT "0") (T "3")`,
+			// The end of round 4 was missed; mp_team1 won it as the terrorists
+			`L 08/04/2019 - 20:03:10: World triggered "Round_Start"`,
+			`L 08/04/2019 - 20:03:31: Game Over: competitive  de_lltest score 0:4 after 4 min`,
+		)
+
+		if len(events) != 1 {
+			t.Fatalf("Expected 1 discrepancy to have been reported not %d.", len(events))
+		}
+
+		if events[0].Source != scoreSourceGameOver {
+			t.Errorf("Expected the discrepancy to be reported on game over not %q.", events[0].Source)
+		}
+
+		match, _ := sut.CurrentMatch()
+		if match.Ended.IsZero() {
+			t.Error("Expected the match to have ended on game over.")
+		}
+
+		if team1, team2 := sut.game.scoresCurrentMatch(); team1 != 4 || team2 != 0 {
+			t.Errorf("Expected a score of 4:0 not %d:%d.", team1, team2)
+		}
+	})
+}
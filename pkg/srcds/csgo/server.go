@@ -9,7 +9,7 @@ import (
 
 // Server represents an interactive CSGO SRCDS instance
 type Server struct {
-	srcds *srcds.Server
+	*srcds.Server
 	Observer
 	wg sync.WaitGroup
 }
@@ -17,19 +17,21 @@ type Server struct {
 // NewServer for interacting with a CSGO SRCDS instance
 func NewServer() *Server {
 	s := &Server{
-		srcds: srcds.NewServer(),
+		Server: srcds.NewServer(),
 	}
 
-	s.Observer.srcdsObserver = s.srcds.Observer
+	s.Observer.srcdsObserver = s.Server.Observer
+	s.Server.OnConsoleLine(s.processConsoleLine)
 
-	s.srcds.AddCvarWatcher("mp_halftime", "mp_maxrounds", "mp_overtime_maxrounds")
+	s.Server.AddCvarWatcher("mp_halftime", "mp_maxrounds", "mp_overtime_maxrounds", "mp_do_warmup", "mp_ct_default_secondary", "mp_t_default_secondary",
+		"mp_overtime_enable", "mp_match_can_clinch", "game_type", "game_mode")
 
 	return s
 }
 
 // SetExec prepares the CSGO SRCDS instance for execution using the given arguments
 func (s *Server) SetExec(arg string, args ...string) error {
-	err := s.srcds.SetExec(arg, args...)
+	err := s.Server.SetExec(arg, args...)
 	if err != nil {
 		return fmt.Errorf("Unable to SetExec for CSGO Server: %w", err)
 	}
@@ -56,7 +58,7 @@ func (s *Server) Read() error {
 
 // Listen starts the CSGO server, processes its output, and returns its log stream
 func (s *Server) Listen() (<-chan srcds.LogEntry, error) {
-	c, err := s.srcds.Listen()
+	c, err := s.Server.Listen()
 	if err != nil {
 		return nil, fmt.Errorf("Couldn't listen to SRCDS server: %w", err)
 	}
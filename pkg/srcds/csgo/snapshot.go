@@ -0,0 +1,149 @@
+package csgo
+
+import (
+	"time"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+)
+
+// MatchSnapshot is a point-in-time copy of a match's state
+type MatchSnapshot struct {
+	Number  int
+	MapName string
+	Started time.Time
+	Ended   time.Time
+	Decided bool   // true once the match has been won or drawn
+	Winner  string // mp_team1 or mp_team2; empty if the match is undecided or was a draw
+	Rounds  []RoundSnapshot
+	Players map[string]PlayerStats // keyed by SteamID
+}
+
+// RoundSnapshot is a point-in-time copy of a completed round's state
+type RoundSnapshot struct {
+	Number             int
+	Started            time.Time
+	WinningAffiliation string
+	WinningTeam        string
+	WinningTrigger     string
+	Bomb               BombSummary
+	Economy            map[string]TeamEconomy // keyed by team (mp_team1/mp_team2)
+	Clutches           []Clutch
+	Players            map[string]PlayerRoundStats // keyed by SteamID
+	Reconstructed      bool                        // true if the round was never observed and was inferred from reported scores
+	Stats              *srcds.RoundStats           // per-player statistics reported by the server; nil if none were reported
+}
+
+// CurrentMatch returns a snapshot of the current match; false if no match has been observed
+func (o *Observer) CurrentMatch() (MatchSnapshot, bool) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	if len(o.game.matches) == 0 {
+		return MatchSnapshot{}, false
+	}
+
+	i := len(o.game.matches) - 1
+	return o.game.matches[i].snapshot(i+1, o.game.playerNames), true
+}
+
+// Matches returns snapshots of every observed match
+func (o *Observer) Matches() []MatchSnapshot {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	r := make([]MatchSnapshot, 0, len(o.game.matches))
+	for i := range o.game.matches {
+		r = append(r, o.game.matches[i].snapshot(i+1, o.game.playerNames))
+	}
+
+	return r
+}
+
+// TeamNames returns the names of mp_team1 and mp_team2
+func (o *Observer) TeamNames() (mpTeamname1, mpTeamname2 string) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	return o.game.mpTeamname1, o.game.mpTeamname2
+}
+
+func (m *matchInfo) snapshot(number int, names map[string]string) MatchSnapshot {
+	r := MatchSnapshot{
+		Number:  number,
+		MapName: m.mapName,
+		Started: m.started,
+		Ended:   m.ended,
+		Decided: m.decided,
+		Winner:  string(m.winner),
+		Rounds:  make([]RoundSnapshot, 0, len(m.rounds)),
+		Players: m.playerTotals(names),
+	}
+
+	for i := range m.rounds {
+		r.Rounds = append(r.Rounds, m.rounds[i].snapshot(i+1))
+	}
+
+	return r
+}
+
+func (r *roundInfo) snapshot(number int) RoundSnapshot {
+	s := RoundSnapshot{
+		Number:             number,
+		Started:            r.started,
+		WinningAffiliation: string(r.winningAffiliation),
+		WinningTeam:        string(r.winningTeam),
+		WinningTrigger:     r.winningTrigger,
+		Bomb:               r.bomb.snapshot(r.winningTrigger),
+		Economy:            make(map[string]TeamEconomy, len(r.economy)),
+		Players:            make(map[string]PlayerRoundStats, len(r.players)),
+		Reconstructed:      r.reconstructed,
+		Stats:              copyRoundStats(r.stats),
+	}
+
+	for _, c := range r.clutches {
+		s.Clutches = append(s.Clutches, Clutch{
+			SteamID:   c.key,
+			Team:      string(c.team),
+			Opponents: c.opponents,
+			Won:       c.team == r.winningTeam,
+		})
+	}
+
+	for t, e := range r.economy {
+		s.Economy[string(t)] = TeamEconomy{
+			Buy:            string(e.buy),
+			EquipmentValue: e.equipmentValue,
+			MoneySpent:     e.moneySpent,
+		}
+	}
+
+	for key, p := range r.players {
+		c := *p
+		c.Equipment = append([]string(nil), p.Equipment...)
+		c.Purchases = append([]string(nil), p.Purchases...)
+		s.Players[key] = c
+	}
+
+	return s
+}
+
+// copyRoundStats deep copies the statistics reported by the server; nil if none were reported
+func copyRoundStats(stats *srcds.RoundStats) *srcds.RoundStats {
+	if stats == nil {
+		return nil
+	}
+
+	c := *stats
+	c.Players = make([]srcds.PlayerRoundStats, 0, len(stats.Players))
+	for _, p := range stats.Players {
+		fields := make(map[string]string, len(p.Fields))
+		for name, value := range p.Fields {
+			fields[name] = value
+		}
+
+		p.Fields = fields
+		c.Players = append(c.Players, p)
+	}
+
+	return &c
+}
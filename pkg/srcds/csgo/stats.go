@@ -0,0 +1,353 @@
+package csgo
+
+import (
+	"time"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+)
+
+// tradeWindow is the maximum amount of time between a death and the killer being killed for the death to count as traded
+const tradeWindow = 5 * time.Second
+
+// killInfo contains information about a single kill
+type killInfo struct {
+	at       time.Time
+	attacker srcds.Client
+	victim   srcds.Client
+	weapon   string
+	headshot bool
+}
+
+// statsKey returns the key used to track a client's statistics; bots share a SteamID so their username is used instead
+func statsKey(c srcds.Client) string {
+	if c.IsBot() {
+		return "BOT:" + c.Username
+	}
+
+	return c.SteamID
+}
+
+// trackable determines if a client should have statistics tracked
+func trackable(c srcds.Client) bool {
+	return !srcds.ClientUnidentifiable(c) && !c.IsConsole()
+}
+
+// activeRound returns the round statistics should currently be applied to; nil if no match has been started
+func (g *gameInfo) activeRound() *roundInfo {
+	if len(g.matches) == 0 {
+		return nil
+	}
+
+	m := &g.matches[len(g.matches)-1]
+
+	if !m.roundLive && len(m.rounds) > 0 {
+		// Post-round actions are attributed to the round that just ended
+		return &m.rounds[len(m.rounds)-1]
+	}
+
+	return &m.current
+}
+
+// enrollPlayer ensures a player has statistics for the active round, as everyone on a team has played the round
+func (g *gameInfo) enrollPlayer(c srcds.Client, t team) {
+	if r := g.activeRound(); r != nil && trackable(c) {
+		s := r.playerStats(c)
+		s.Team = string(t)
+		g.seedMoney(s, c)
+	}
+}
+
+// recordAssist credits a client for assisting in a kill
+func (g *gameInfo) recordAssist(assister srcds.Client, m clientAssisted) {
+	r := g.activeRound()
+	if r == nil || !trackable(assister) || assister.Affiliation == m.victim.Affiliation {
+		return
+	}
+
+	if m.flash {
+		r.playerStats(assister).FlashAssists++
+		return
+	}
+
+	r.playerStats(assister).Assists++
+}
+
+// recordDamage credits a client for damage dealt to an enemy; damage is capped to the victim's remaining health
+func (g *gameInfo) recordDamage(attacker srcds.Client, m clientAttacked) {
+	r := g.activeRound()
+	if r == nil || !trackable(attacker) || !trackable(m.victim) {
+		return
+	}
+
+	if r.health == nil {
+		r.health = make(map[string]int)
+	}
+
+	victimKey := statsKey(m.victim)
+	previous, found := r.health[victimKey]
+	if !found {
+		previous = 100
+	}
+
+	r.health[victimKey] = m.health
+
+	if attacker.Affiliation == m.victim.Affiliation {
+		return
+	}
+
+	dealt := previous - m.health
+	if dealt > m.damage {
+		dealt = m.damage
+	}
+
+	if dealt > 0 {
+		attackerStats := r.playerStats(attacker)
+		attackerStats.Damage += dealt
+
+		if isUtilityWeapon(m.weapon) {
+			attackerStats.UtilityDamage += dealt
+		}
+	}
+}
+
+// recordDeath records a client dying without being killed by another client (suicide, bomb, fall damage, etc)
+func (g *gameInfo) recordDeath(at time.Time, victim srcds.Client) {
+	if r := g.activeRound(); r != nil && trackable(victim) {
+		r.playerStats(victim).Deaths++
+		g.recordDeparture(at, statsKey(victim))
+	}
+}
+
+// recordKill credits the attacker with a kill and the victim with a death, updating first-kill and trade information
+func (g *gameInfo) recordKill(at time.Time, attacker srcds.Client, m clientKilled) {
+	r := g.activeRound()
+	if r == nil || !trackable(m.victim) {
+		return
+	}
+
+	victimStats := r.playerStats(m.victim)
+	victimStats.Deaths++
+	defer g.recordDeparture(at, statsKey(m.victim))
+
+	if !trackable(attacker) {
+		return
+	}
+
+	attackerStats := r.playerStats(attacker)
+
+	if attacker.Affiliation == m.victim.Affiliation {
+		attackerStats.TeamKills++
+		return
+	}
+
+	attackerStats.Kills++
+	if m.headshot {
+		attackerStats.HeadshotKills++
+	}
+
+	if len(r.kills) == 0 {
+		attackerStats.FirstKill = true
+		victimStats.FirstDeath = true
+	}
+
+	// A teammate of anyone the victim recently killed has traded that death
+	for _, k := range r.kills {
+		if srcds.ClientsAreEquivalent(k.attacker, m.victim) && k.victim.Affiliation == attacker.Affiliation && at.Sub(k.at) <= tradeWindow {
+			r.playerStats(k.victim).Traded = true
+		}
+	}
+
+	r.kills = append(r.kills, killInfo{
+		at:       at,
+		attacker: attacker,
+		victim:   m.victim,
+		weapon:   m.weapon,
+		headshot: m.headshot,
+	})
+}
+
+// sawClient keeps track of the most recent username of a client
+func (g *gameInfo) sawClient(c srcds.Client) {
+	if !srcds.ClientUnidentifiable(c) && len(c.Username) > 0 {
+		if g.playerNames == nil {
+			g.playerNames = make(map[string]string)
+		}
+
+		g.playerNames[statsKey(c)] = c.Username
+	}
+}
+
+// playerStats returns the (possibly new) statistics of a client for the round
+func (r *roundInfo) playerStats(c srcds.Client) *PlayerRoundStats {
+	if r.players == nil {
+		r.players = make(map[string]*PlayerRoundStats)
+	}
+
+	key := statsKey(c)
+	s, found := r.players[key]
+
+	if !found {
+		s = &PlayerRoundStats{}
+		r.players[key] = s
+	}
+
+	if aff, _ := parseAffiliation(c.Affiliation); aff == counterterrorist || aff == terrorist {
+		s.Affiliation = string(aff)
+	}
+
+	return s
+}
+
+// playerTotals sums the statistics of every player across every completed round of the match; the round being played
+// isn't counted until it is over so KAST and ADR aren't skewed mid-round
+func (m *matchInfo) playerTotals(names map[string]string) map[string]PlayerStats {
+	r := make(map[string]PlayerStats)
+
+	for _, round := range m.rounds {
+		for key, s := range round.players {
+			total := r[key]
+			total.add(*s)
+			r[key] = total
+		}
+	}
+
+	for key, total := range r {
+		total.SteamID = key
+		total.Username = names[key]
+		r[key] = total
+	}
+
+	return r
+}
+
+// PlayerRoundStats contains a player's statistics for a single round
+type PlayerRoundStats struct {
+	Affiliation   string
+	Team          string
+	Kills         int
+	Deaths        int
+	Assists       int
+	FlashAssists  int
+	Damage        int
+	HeadshotKills int
+	TeamKills     int
+	FirstKill     bool
+	FirstDeath    bool
+	Traded        bool
+
+	HEGrenadesThrown int
+	FlashbangsThrown int
+	SmokesThrown     int
+	MolotovsThrown   int // includes incendiary grenades
+	DecoysThrown     int
+	EnemiesFlashed   int
+	TeammatesFlashed int
+	EnemyBlindTime   float64 // seconds
+	UtilityDamage    int
+
+	MoneyStart     int
+	MoneySpent     int
+	EquipmentValue int
+	Equipment      []string // carried out of the buyzone
+	Purchases      []string
+	moneyKnown     bool
+}
+
+// KAST determines if the player got a kill, assist, survived, or was traded during the round
+func (s PlayerRoundStats) KAST() bool {
+	return s.Kills > 0 || s.Assists > 0 || s.FlashAssists > 0 || s.Deaths == 0 || s.Traded
+}
+
+// PlayerStats contains a player's accumulated statistics for a match
+type PlayerStats struct {
+	SteamID       string
+	Username      string
+	RoundsPlayed  int
+	Kills         int
+	Deaths        int
+	Assists       int
+	FlashAssists  int
+	Damage        int
+	HeadshotKills int
+	TeamKills     int
+	KASTRounds    int
+	FirstKills    int
+	FirstDeaths   int
+
+	HEGrenadesThrown int
+	FlashbangsThrown int
+	SmokesThrown     int
+	MolotovsThrown   int // includes incendiary grenades
+	DecoysThrown     int
+	EnemiesFlashed   int
+	TeammatesFlashed int
+	EnemyBlindTime   float64 // seconds
+	UtilityDamage    int
+
+	MoneySpent int
+}
+
+// ADR is the average damage dealt per round played
+func (p PlayerStats) ADR() float64 {
+	if p.RoundsPlayed == 0 {
+		return 0
+	}
+
+	return float64(p.Damage) / float64(p.RoundsPlayed)
+}
+
+// EntryDuels is the number of rounds the player was involved in the round's opening kill
+func (p PlayerStats) EntryDuels() int {
+	return p.FirstKills + p.FirstDeaths
+}
+
+// HeadshotPercentage is the percentage of kills that were headshots
+func (p PlayerStats) HeadshotPercentage() float64 {
+	if p.Kills == 0 {
+		return 0
+	}
+
+	return float64(p.HeadshotKills) / float64(p.Kills) * 100
+}
+
+// KAST is the percentage of rounds played in which the player got a kill, assist, survived, or was traded
+func (p PlayerStats) KAST() float64 {
+	if p.RoundsPlayed == 0 {
+		return 0
+	}
+
+	return float64(p.KASTRounds) / float64(p.RoundsPlayed) * 100
+}
+
+func (p *PlayerStats) add(s PlayerRoundStats) {
+	p.RoundsPlayed++
+	p.Kills += s.Kills
+	p.Deaths += s.Deaths
+	p.Assists += s.Assists
+	p.FlashAssists += s.FlashAssists
+	p.Damage += s.Damage
+	p.HeadshotKills += s.HeadshotKills
+	p.TeamKills += s.TeamKills
+	p.HEGrenadesThrown += s.HEGrenadesThrown
+	p.FlashbangsThrown += s.FlashbangsThrown
+	p.SmokesThrown += s.SmokesThrown
+	p.MolotovsThrown += s.MolotovsThrown
+	p.DecoysThrown += s.DecoysThrown
+	p.EnemiesFlashed += s.EnemiesFlashed
+	p.TeammatesFlashed += s.TeammatesFlashed
+	p.EnemyBlindTime += s.EnemyBlindTime
+	p.UtilityDamage += s.UtilityDamage
+	p.MoneySpent += s.MoneySpent
+
+	if s.KAST() {
+		p.KASTRounds++
+	}
+
+	if s.FirstKill {
+		p.FirstKills++
+	}
+
+	if s.FirstDeath {
+		p.FirstDeaths++
+	}
+}
@@ -0,0 +1,148 @@
+package csgo
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	statsAlpha = `"Alpha<3><STEAM_1:0:1001><CT>"`
+	statsBravo = `"Bravo<4><STEAM_1:0:1002><CT>"`
+	statsXray  = `"Xray<5><STEAM_1:0:2001><TERRORIST>"`
+	statsYanke = `"Yankee<6><STEAM_1:0:2002><TERRORIST>"`
+)
+
+func observeLines(t *testing.T, lines ...string) *Observer {
+	t.Helper()
+
+	sut := NewObserver(1, 30, 6)
+	observeInto(t, sut, lines...)
+
+	return sut
+}
+
+func observeInto(t *testing.T, sut *Observer, lines ...string) {
+	t.Helper()
+	zerolog.SetGlobalLevel(zerolog.ErrorLevel)
+
+	sut.Read(strings.NewReader(strings.Join(lines, "\n") + "\n"))
+	sut.Wait()
+}
+
+func Test_PlayerStats(t *testing.T) {
+	sut := observeLines(t,
+		`L 08/04/2019 - 20:00:00: World triggered "Match_Start" on "de_lltest"`,
+		`L 08/04/2019 - 20:00:01: "Alpha<3><STEAM_1:0:1001>" switched from team <Unassigned> to <CT>`,
+		`L 08/04/2019 - 20:00:01: "Bravo<4><STEAM_1:0:1002>" switched from team <Unassigned> to <CT>`,
+		`L 08/04/2019 - 20:00:01: "Xray<5><STEAM_1:0:2001>" switched from team <Unassigned> to <TERRORIST>`,
+		`L 08/04/2019 - 20:00:01: "Yankee<6><STEAM_1:0:2002>" switched from team <Unassigned> to <TERRORIST>`,
+		// Round 1
+		`L 08/04/2019 - 20:00:10: World triggered "Round_Start"`,
+		`L 08/04/2019 - 20:00:20: `+statsXray+` [0 0 0] attacked `+statsAlpha+` [0 0 0] with "ak47" (damage "60") (damage_armor "0") (health "40") (armor "0") (hitgroup "chest")`,
+		`L 08/04/2019 - 20:00:21: `+statsXray+` [0 0 0] attacked `+statsAlpha+` [0 0 0] with "ak47" (damage "111") (damage_armor "0") (health "0") (armor "0") (hitgroup "head")`,
+		`L 08/04/2019 - 20:00:21: `+statsXray+` [0 0 0] killed `+statsAlpha+` [0 0 0] with "ak47" (headshot)`,
+		`L 08/04/2019 - 20:00:21: `+statsYanke+` assisted killing `+statsAlpha,
+		`L 08/04/2019 - 20:00:23: `+statsBravo+` [0 0 0] killed `+statsXray+` [0 0 0] with "m4a1"`,
+		`L 08/04/2019 - 20:00:30: `+statsYanke+` [0 0 0] killed `+statsBravo+` [0 0 0] with "ak47"`,
+		`L 08/04/2019 - 20:00:31: Team "TERRORIST" triggered "SFUI_Notice_Terrorists_Win" (CT "0") (T "1")`,
+		// Round 2; Alpha reconnects with a new name
+		`L 08/04/2019 - 20:00:35: "Alpha<3><STEAM_1:0:1001><CT>" disconnected (reason "Disconnect")`,
+		`L 08/04/2019 - 20:00:36: "Alpha Prime<7><STEAM_1:0:1001><>" connected, address ""`,
+		`L 08/04/2019 - 20:00:37: "Alpha Prime<7><STEAM_1:0:1001>" switched from team <Unassigned> to <CT>`,
+		`L 08/04/2019 - 20:00:40: World triggered "Round_Start"`,
+		`L 08/04/2019 - 20:00:50: "Alpha Prime<7><STEAM_1:0:1001><CT>" [0 0 0] killed `+statsYanke+` [0 0 0] with "usp_silencer" (headshot penetrated)`,
+		`L 08/04/2019 - 20:00:55: "Alpha Prime<7><STEAM_1:0:1001><CT>" [0 0 0] killed `+statsXray+` [0 0 0] with "usp_silencer"`,
+		`L 08/04/2019 - 20:00:56: Team "CT" triggered "SFUI_Notice_CTs_Win" (CT "1") (T "1")`,
+	)
+
+	match, ok := sut.CurrentMatch()
+	if !ok {
+		t.Fatal("Expected a current match to have been observed.")
+	}
+
+	if len(match.Rounds) != 2 {
+		t.Fatalf("Expected 2 completed rounds not %d.", len(match.Rounds))
+	}
+
+	tests := map[string]PlayerStats{
+		"STEAM_1:0:1001": {Username: "Alpha Prime", RoundsPlayed: 2, Kills: 2, Deaths: 1, HeadshotKills: 1, KASTRounds: 2, FirstKills: 1, FirstDeaths: 1},
+		"STEAM_1:0:1002": {Username: "Bravo", RoundsPlayed: 2, Kills: 1, Deaths: 1, KASTRounds: 2},
+		"STEAM_1:0:2001": {Username: "Xray", RoundsPlayed: 2, Kills: 1, Deaths: 2, Damage: 100, HeadshotKills: 1, KASTRounds: 1, FirstKills: 1},
+		"STEAM_1:0:2002": {Username: "Yankee", RoundsPlayed: 2, Kills: 1, Deaths: 1, Assists: 1, KASTRounds: 1, FirstDeaths: 1},
+	}
+
+	for steamID, expected := range tests {
+		expected.SteamID = steamID
+
+		if actual := match.Players[steamID]; actual != expected {
+			t.Errorf("Expected stats for %q to be %+v not %+v.", steamID, expected, actual)
+		}
+	}
+
+	if !match.Rounds[0].Players["STEAM_1:0:1001"].Traded {
+		t.Error("Alpha's death in round 1 should have been traded by Bravo.")
+	}
+
+	if match.Rounds[0].Players["STEAM_1:0:2001"].Traded {
+		t.Error("Xray's death in round 1 should not have been traded.")
+	}
+}
+
+func Test_PlayerStats_liveRoundExcluded(t *testing.T) {
+	sut := observeLines(t,
+		`L 08/04/2019 - 20:00:00: World triggered "Match_Start" on "de_lltest"`,
+		`L 08/04/2019 - 20:00:01: "Alpha<3><STEAM_1:0:1001>" switched from team <Unassigned> to <CT>`,
+		`L 08/04/2019 - 20:00:01: "Xray<5><STEAM_1:0:2001>" switched from team <Unassigned> to <TERRORIST>`,
+		`L 08/04/2019 - 20:00:10: World triggered "Round_Start"`,
+		`L 08/04/2019 - 20:00:21: `+statsXray+` [0 0 0] killed `+statsAlpha+` [0 0 0] with "ak47"`,
+		`L 08/04/2019 - 20:00:31: Team "TERRORIST" triggered "SFUI_Notice_Terrorists_Win" (CT "0") (T "1")`,
+		// Round 2 is still being played
+		`L 08/04/2019 - 20:00:40: World triggered "Round_Start"`,
+		`L 08/04/2019 - 20:00:45: `+statsXray+` [0 0 0] attacked `+statsAlpha+` [0 0 0] with "ak47" (damage "60") (damage_armor "0") (health "40") (armor "0") (hitgroup "chest")`,
+	)
+
+	match, ok := sut.CurrentMatch()
+	if !ok {
+		t.Fatal("Expected a current match to have been observed.")
+	}
+
+	tests := map[string]PlayerStats{
+		"STEAM_1:0:1001": {Username: "Alpha", RoundsPlayed: 1, Deaths: 1, FirstDeaths: 1},
+		"STEAM_1:0:2001": {Username: "Xray", RoundsPlayed: 1, Kills: 1, KASTRounds: 1, FirstKills: 1},
+	}
+
+	for steamID, expected := range tests {
+		expected.SteamID = steamID
+
+		if actual := match.Players[steamID]; actual != expected {
+			t.Errorf("Expected stats for %q to only include completed rounds %+v not %+v.", steamID, expected, actual)
+		}
+	}
+}
+
+func Test_PlayerStats_Percentages(t *testing.T) {
+	sut := PlayerStats{RoundsPlayed: 4, Kills: 4, HeadshotKills: 1, Damage: 350, KASTRounds: 3, FirstKills: 2, FirstDeaths: 1}
+
+	if actual := sut.ADR(); actual != 87.5 {
+		t.Errorf("Expected ADR of 87.5 not %f.", actual)
+	}
+
+	if actual := sut.HeadshotPercentage(); actual != 25 {
+		t.Errorf("Expected headshot percentage of 25 not %f.", actual)
+	}
+
+	if actual := sut.KAST(); actual != 75 {
+		t.Errorf("Expected KAST of 75 not %f.", actual)
+	}
+
+	if actual := sut.EntryDuels(); actual != 3 {
+		t.Errorf("Expected 3 entry duels not %d.", actual)
+	}
+
+	empty := PlayerStats{}
+	if empty.ADR() != 0 || empty.HeadshotPercentage() != 0 || empty.KAST() != 0 {
+		t.Error("Statistics without any rounds played should not divide by zero.")
+	}
+}
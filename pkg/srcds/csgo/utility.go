@@ -0,0 +1,66 @@
+package csgo
+
+import (
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+)
+
+// isUtilityWeapon determines if damage dealt with the weapon counts as utility damage
+func isUtilityWeapon(weapon string) bool {
+	switch weapon {
+	case string(grenadeHE), string(grenadeMolotov), string(grenadeIncendiary), "inferno":
+		return true
+	}
+
+	return false
+}
+
+// recordBlinded credits the owner of a flashbang for blinding a client
+func (g *gameInfo) recordBlinded(victim srcds.Client, m clientBlinded) {
+	r := g.activeRound()
+	if r == nil || !trackable(victim) || !trackable(m.attacker) || srcds.ClientsAreEquivalent(victim, m.attacker) {
+		return
+	}
+
+	attackerStats := r.playerStats(m.attacker)
+
+	if victim.Affiliation == m.attacker.Affiliation {
+		attackerStats.TeammatesFlashed++
+		return
+	}
+
+	attackerStats.EnemiesFlashed++
+	attackerStats.EnemyBlindTime += m.duration
+}
+
+// recordGrenadeThrown credits a client for using utility
+func (g *gameInfo) recordGrenadeThrown(c srcds.Client, gr grenade) {
+	r := g.activeRound()
+	if r == nil || !trackable(c) {
+		return
+	}
+
+	s := r.playerStats(c)
+
+	switch gr {
+	case grenadeHE:
+		s.HEGrenadesThrown++
+	case grenadeFlashbang:
+		s.FlashbangsThrown++
+	case grenadeSmoke:
+		s.SmokesThrown++
+	case grenadeMolotov, grenadeIncendiary:
+		s.MolotovsThrown++
+	case grenadeDecoy:
+		s.DecoysThrown++
+	}
+}
+
+// UtilityThrown is the total number of grenades thrown during the round
+func (s PlayerRoundStats) UtilityThrown() int {
+	return s.HEGrenadesThrown + s.FlashbangsThrown + s.SmokesThrown + s.MolotovsThrown + s.DecoysThrown
+}
+
+// UtilityThrown is the total number of grenades thrown during the match
+func (p PlayerStats) UtilityThrown() int {
+	return p.HEGrenadesThrown + p.FlashbangsThrown + p.SmokesThrown + p.MolotovsThrown + p.DecoysThrown
+}
@@ -0,0 +1,46 @@
+package csgo
+
+import (
+	"testing"
+)
+
+func Test_UtilityStats(t *testing.T) {
+	sut := observeLines(t,
+		`L 08/04/2019 - 20:00:00: World triggered "Match_Start" on "de_lltest"`,
+		`L 08/04/2019 - 20:00:10: World triggered "Round_Start"`,
+		`L 08/04/2019 - 20:00:11: `+statsXray+` threw flashbang [854 239 -187] flashbang entindex 128)`,
+		`L 08/04/2019 - 20:00:12: `+statsAlpha+` blinded for 2.50 by `+statsXray+` from flashbang entindex 128`,
+		`L 08/04/2019 - 20:00:12: `+statsBravo+` blinded for 1.25 by `+statsXray+` from flashbang entindex 128`,
+		`L 08/04/2019 - 20:00:12: `+statsYanke+` blinded for 3.00 by `+statsXray+` from flashbang entindex 128`,
+		`L 08/04/2019 - 20:00:12: `+statsXray+` blinded for 3.00 by `+statsXray+` from flashbang entindex 128`,
+		`L 08/04/2019 - 20:00:13: `+statsXray+` threw hegrenade [1 2 3]`,
+		`L 08/04/2019 - 20:00:14: `+statsXray+` [0 0 0] attacked `+statsAlpha+` [0 0 0] with "hegrenade" (damage "45") (damage_armor "3") (health "55") (armor "97") (hitgroup "generic")`,
+		`L 08/04/2019 - 20:00:15: `+statsYanke+` threw molotov [1 2 3]`,
+		`L 08/04/2019 - 20:00:16: `+statsYanke+` [0 0 0] attacked `+statsAlpha+` [0 0 0] with "inferno" (damage "8") (damage_armor "0") (health "47") (armor "97") (hitgroup "generic")`,
+		`L 08/04/2019 - 20:00:17: `+statsAlpha+` threw smokegrenade [1 2 3]`,
+		`L 08/04/2019 - 20:00:17: `+statsAlpha+` threw decoy [1 2 3]`,
+		`L 08/04/2019 - 20:00:17: `+statsAlpha+` threw incgrenade [1 2 3]`,
+		`L 08/04/2019 - 20:00:18: Team "CT" triggered "SFUI_Notice_CTs_Win" (CT "1") (T "0")`,
+	)
+
+	match, ok := sut.CurrentMatch()
+	if !ok {
+		t.Fatal("Expected a current match to have been observed.")
+	}
+
+	xray := match.Players["STEAM_1:0:2001"]
+	if xray.FlashbangsThrown != 1 || xray.HEGrenadesThrown != 1 || xray.EnemiesFlashed != 2 || xray.TeammatesFlashed != 1 ||
+		xray.EnemyBlindTime != 3.75 || xray.UtilityDamage != 45 || xray.UtilityThrown() != 2 {
+		t.Errorf("Utility for Xray was not recorded correctly: %+v", xray)
+	}
+
+	yankee := match.Players["STEAM_1:0:2002"]
+	if yankee.MolotovsThrown != 1 || yankee.UtilityDamage != 8 || yankee.Damage != 8 {
+		t.Errorf("Utility for Yankee was not recorded correctly: %+v", yankee)
+	}
+
+	alpha := match.Rounds[0].Players["STEAM_1:0:1001"]
+	if alpha.SmokesThrown != 1 || alpha.DecoysThrown != 1 || alpha.MolotovsThrown != 1 || alpha.UtilityThrown() != 3 {
+		t.Errorf("Utility for Alpha was not recorded correctly: %+v", alpha)
+	}
+}
@@ -7,6 +7,9 @@ import (
 	"time"
 )
 
+// cvarHistorySize is the number of changes remembered for each cvar
+const cvarHistorySize = 32
+
 // Cvar represents a watched SRCDS console variable
 type Cvar struct {
 	LastUpdated time.Time
@@ -14,10 +17,89 @@ type Cvar struct {
 	seededValue bool
 }
 
+// CvarSource identifies where a cvar's value came from
+type CvarSource string
+
+const (
+	// CvarSourceSeed is a default value provided when the cvar was watched
+	CvarSourceSeed CvarSource = "seed"
+	// CvarSourceLog is a value reported in the log stream (e.g. server_cvar)
+	CvarSourceLog CvarSource = "log"
+	// CvarSourceConsole is a value echoed on the console in response to the cvar's name being sent
+	CvarSourceConsole CvarSource = "console"
+)
+
+// CvarChange describes a watched cvar taking on a new value
+type CvarChange struct {
+	Name     string
+	OldValue string
+	NewValue string
+	At       time.Time
+	Source   CvarSource
+	Initial  bool // true if the cvar had no value before the change
+}
+
 // Cvars represents a collection of watched console variables
 type Cvars struct {
-	v   map[string]Cvar
-	mux sync.Mutex
+	v        map[string]Cvar
+	handlers []func(CvarChange)
+	history  map[string][]CvarChange
+	mux      sync.Mutex
+}
+
+// onChange registers a handler to be called whenever a watched cvar's value changes
+func (c *Cvars) onChange(h func(CvarChange)) {
+	c.mux.Lock()
+	c.handlers = append(c.handlers, h)
+	c.mux.Unlock()
+}
+
+// changes returns the remembered changes of a cvar, oldest first
+func (c *Cvars) changes(name string) []CvarChange {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	return append([]CvarChange(nil), c.history[strings.TrimSpace(name)]...)
+}
+
+// recordChange remembers a change if the value differs from the previous one, returning the handlers to notify; the
+// caller must hold the lock
+func (c *Cvars) recordChange(name string, previous Cvar, next Cvar, source CvarSource) (CvarChange, []func(CvarChange), bool) {
+	hadValue := previous.seededValue || !previous.LastUpdated.IsZero()
+	if hadValue && previous.Value == next.Value {
+		return CvarChange{}, nil, false
+	}
+
+	change := CvarChange{
+		Name:     name,
+		OldValue: previous.Value,
+		NewValue: next.Value,
+		At:       next.LastUpdated,
+		Source:   source,
+		Initial:  !hadValue,
+	}
+
+	if change.At.IsZero() {
+		change.At = time.Now()
+	}
+
+	if c.history == nil {
+		c.history = make(map[string][]CvarChange)
+	}
+
+	h := append(c.history[name], change)
+	if len(h) > cvarHistorySize {
+		h = append([]CvarChange(nil), h[len(h)-cvarHistorySize:]...)
+	}
+	c.history[name] = h
+
+	return change, c.handlers, true
+}
+
+func notifyCvarChange(change CvarChange, handlers []func(CvarChange)) {
+	for _, h := range handlers {
+		h(change)
+	}
 }
 
 func (c *Cvars) addWatcher(names ...string) {
@@ -64,21 +146,33 @@ func (c *Cvars) seedWatcher(name, value string) {
 		return
 	}
 
+	c.mux.Lock()
 	if c.v == nil {
 		c.v = make(map[string]Cvar)
 	}
 
-	c.mux.Lock()
-	if cvar, found := c.v[name]; !found {
-		c.v[name] = Cvar{Value: strings.TrimSpace(value), seededValue: true}
-	} else if cvar.LastUpdated.IsZero() {
+	cvar, found := c.v[name]
+	if found && !cvar.LastUpdated.IsZero() {
 		// seed the value only if it hasn't been naturally found
-		c.v[name] = Cvar{Value: strings.TrimSpace(value), seededValue: true}
+		c.mux.Unlock()
+		return
 	}
+
+	next := Cvar{Value: strings.TrimSpace(value), seededValue: true}
+	c.v[name] = next
+	change, handlers, changed := c.recordChange(name, cvar, next, CvarSourceSeed)
 	c.mux.Unlock()
+
+	if changed {
+		notifyCvarChange(change, handlers)
+	}
 }
 
 func (c *Cvars) setIfWatched(name, value string, asOf time.Time) {
+	c.setIfWatchedFrom(name, value, asOf, CvarSourceLog)
+}
+
+func (c *Cvars) setIfWatchedFrom(name, value string, asOf time.Time, source CvarSource) {
 	name = strings.TrimSpace(name)
 
 	if len(name) == 0 {
@@ -92,14 +186,44 @@ func (c *Cvars) setIfWatched(name, value string, asOf time.Time) {
 	}
 
 	c.mux.Lock()
-	if _, found := c.v[name]; found {
-		c.v[name] = Cvar{
-			LastUpdated: asOf,
-			Value:       strings.TrimSpace(value),
-			seededValue: false,
-		}
+	previous, found := c.v[name]
+	if !found {
+		c.mux.Unlock()
+		return
+	}
+
+	next := Cvar{
+		LastUpdated: asOf,
+		Value:       strings.TrimSpace(value),
+		seededValue: false,
 	}
+	c.v[name] = next
+	change, handlers, changed := c.recordChange(name, previous, next, source)
 	c.mux.Unlock()
+
+	if changed {
+		notifyCvarChange(change, handlers)
+	}
+}
+
+func (c *Cvars) tryBool(name string, fallback bool) (value bool, nonFallback bool) {
+	str, nonFallback := c.tryString(name, "")
+
+	if !nonFallback {
+		return fallback, nonFallback
+	}
+
+	if b, err := strconv.ParseBool(str); err == nil {
+		return b, true
+	}
+
+	// SRCDS treats any non-zero number as true
+	f, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return fallback, false
+	}
+
+	return f != 0, true
 }
 
 func (c *Cvars) tryFloat(name string, fallback float32) (value float32, nonFallback bool) {
@@ -132,6 +256,24 @@ func (c *Cvars) tryInt(name string, fallback int) (value int, nonFallback bool)
 	return i, true
 }
 
+// reportedSince returns a cvar's value if the server reported it (in the log stream or on the console) at or after the
+// given time; seeded values and those reported earlier aren't returned
+func (c *Cvars) reportedSince(name string, since time.Time) (value string, reported bool) {
+	if c == nil {
+		return "", false
+	}
+
+	c.mux.Lock()
+	cvar, found := c.v[name]
+	c.mux.Unlock()
+
+	if !found || cvar.LastUpdated.IsZero() || cvar.LastUpdated.Before(since) {
+		return "", false
+	}
+
+	return cvar.Value, true
+}
+
 func (c *Cvars) tryString(name, fallback string) (value string, nonFallback bool) {
 	if c == nil {
 		return fallback, false
@@ -203,3 +203,65 @@ func Test_Cvars(t *testing.T) {
 		}
 	})
 }
+
+func Test_Cvars_changes(t *testing.T) {
+	sut := &Cvars{}
+	actual := []CvarChange{}
+	sut.onChange(func(c CvarChange) { actual = append(actual, c) })
+
+	t0 := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	sut.seedWatcher("mp_maxrounds", "30")
+	sut.setIfWatched("mp_maxrounds", "30", t0)
+	sut.setIfWatchedFrom("mp_maxrounds", "24", t0.Add(time.Minute), CvarSourceConsole)
+	sut.setIfWatched("mp_not_watched", "1", t0)
+
+	expected := []CvarChange{
+		{Name: "mp_maxrounds", NewValue: "30", Source: CvarSourceSeed, Initial: true},
+		{Name: "mp_maxrounds", OldValue: "30", NewValue: "24", At: t0.Add(time.Minute), Source: CvarSourceConsole},
+	}
+
+	if len(actual) != len(expected) {
+		t.Fatalf("Expected changes %+v not %+v.", expected, actual)
+	}
+
+	for i := range expected {
+		if i == 0 {
+			// Seeded values are stamped with the time they were seeded
+			expected[i].At = actual[i].At
+		}
+
+		if actual[i] != expected[i] {
+			t.Errorf("Expected change %+v not %+v.", expected[i], actual[i])
+		}
+	}
+
+	for i := 0; i < cvarHistorySize+5; i++ {
+		sut.setIfWatched("mp_maxrounds", fmt.Sprint(i), t0)
+	}
+
+	history := sut.changes("mp_maxrounds")
+	if len(history) != cvarHistorySize || history[len(history)-1].NewValue != fmt.Sprint(cvarHistorySize+4) {
+		t.Errorf("Expected the history to keep the latest %d changes not %+v.", cvarHistorySize, history)
+	}
+}
+
+func Test_Cvars_tryBool(t *testing.T) {
+	validCases := map[string]bool{"1": true, "0": false, "true": true, "FALSE": false, "2": true, "0.0": false}
+
+	for input, expected := range validCases {
+		sut := &Cvars{}
+		sut.addWatcher("sv_cheats")
+		sut.setIfWatched("sv_cheats", input, time.Now())
+
+		if actual, ok := sut.tryBool("sv_cheats", !expected); !ok || actual != expected {
+			t.Errorf("Expected %q to be %v not %v.", input, expected, actual)
+		}
+	}
+
+	sut := &Cvars{}
+	sut.seedWatcher("hostname", "Laclede's LAN")
+	if actual, ok := sut.tryBool("hostname", true); ok || !actual {
+		t.Error("A non-boolean cvar should have returned the fallback value.")
+	}
+}
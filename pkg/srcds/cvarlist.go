@@ -0,0 +1,173 @@
+package srcds
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// CvarInfo describes a console variable (or command) as listed by cvarlist
+type CvarInfo struct {
+	Name        string
+	Value       string // empty for commands
+	IsCommand   bool
+	Flags       []string // e.g. sv, cheat, rep, or nf
+	Description string
+}
+
+// HasFlag determines if the cvar was listed with the specified flag
+func (i CvarInfo) HasFlag(flag string) bool {
+	for _, f := range i.Flags {
+		if strings.EqualFold(f, flag) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsNumeric determines if the cvar holds a number, as judged by the value it was listed with
+func (i CvarInfo) IsNumeric() bool {
+	if i.IsCommand {
+		return false
+	}
+
+	_, err := strconv.ParseFloat(i.Value, 64)
+	return err == nil
+}
+
+// CvarRegistry contains the cvars and commands reported by cvarlist
+type CvarRegistry struct {
+	listed time.Time
+	mux    sync.Mutex
+	total  int
+	v      map[string]CvarInfo
+}
+
+func (r *CvarRegistry) add(info CvarInfo) {
+	r.mux.Lock()
+	if r.v == nil {
+		r.v = make(map[string]CvarInfo)
+	}
+	r.v[info.Name] = info
+	r.mux.Unlock()
+}
+
+// complete marks the listing as finished
+func (r *CvarRegistry) complete(total int, at time.Time) {
+	r.mux.Lock()
+	r.listed, r.total = at, total
+	listed := len(r.v)
+	r.mux.Unlock()
+
+	log.Info().Int("total", total).Int("parsed", listed).Msg("Cvar list received")
+}
+
+// isListed determines if a complete cvarlist has been received
+func (r *CvarRegistry) isListed() bool {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	return !r.listed.IsZero()
+}
+
+func (r *CvarRegistry) lookup(name string) (CvarInfo, bool) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	info, found := r.v[strings.TrimSpace(name)]
+	return info, found
+}
+
+// snapshot returns every listed cvar (excluding commands) sorted by name
+func (r *CvarRegistry) snapshot() []CvarInfo {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	s := make([]CvarInfo, 0, len(r.v))
+	for _, info := range r.v {
+		if !info.IsCommand {
+			info.Flags = append([]string(nil), info.Flags...)
+			s = append(s, info)
+		}
+	}
+
+	sort.Slice(s, func(i, j int) bool { return s[i].Name < s[j].Name })
+
+	return s
+}
+
+// validate determines if the value can be assigned to the cvar; everything is valid until cvarlist has been received
+func (r *CvarRegistry) validate(name, value string) error {
+	if !r.isListed() {
+		return nil
+	}
+
+	info, found := r.lookup(name)
+	if !found {
+		return fmt.Errorf("Cvar %q is unknown to the server", name)
+	}
+
+	if info.IsCommand {
+		return fmt.Errorf("%q is a command not a cvar", name)
+	}
+
+	if _, err := strconv.ParseFloat(strings.TrimSpace(value), 64); info.IsNumeric() && err != nil {
+		return fmt.Errorf("Cvar %q requires a number not %q", name, value)
+	}
+
+	return nil
+}
+
+// CvarInfo returns the details cvarlist reported for a cvar or command
+func (o *Observer) CvarInfo(name string) (CvarInfo, bool) {
+	return o.cvarList.lookup(name)
+}
+
+// CvarSnapshot returns every cvar reported by the most recent cvarlist, sorted by name
+func (o *Observer) CvarSnapshot() []CvarInfo {
+	return o.cvarList.snapshot()
+}
+
+// ValidateCvar determines if the value can be assigned to the cvar according to cvarlist; nil if cvarlist has yet to be
+// received
+func (o *Observer) ValidateCvar(name, value string) error {
+	return o.cvarList.validate(name, value)
+}
+
+// UnknownCvarWatchers returns the watched cvars that cvarlist didn't report; empty if cvarlist has yet to be received
+func (o *Observer) UnknownCvarWatchers() []string {
+	if !o.cvarList.isListed() {
+		return nil
+	}
+
+	var r []string
+	for _, name := range o.cvars.getNames() {
+		if info, found := o.cvarList.lookup(name); !found || info.IsCommand {
+			r = append(r, name)
+		}
+	}
+
+	sort.Strings(r)
+
+	return r
+}
+
+// completeCvarList records the end of cvarlist output, warning about watchers of cvars the server doesn't have
+func (o *Observer) completeCvarList(total int) {
+	o.cvarList.complete(total, time.Now())
+
+	for _, name := range o.UnknownCvarWatchers() {
+		log.Warn().Str("cvar", name).Msg("Watched cvar is unknown to the server")
+	}
+}
+
+// RefreshCvarList triggers SRCDS into listing every cvar and command
+func (s *Server) RefreshCvarList() {
+	s.SendCommand("cvarlist")
+}
@@ -0,0 +1,60 @@
+package srcds
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_Observer_cvarList(t *testing.T) {
+	stream := strings.Join([]string{
+		`cvar list`,
+		`--------------`,
+		`changelevel                              : cmd      :                  : Change server to the specified map`,
+		`hostname                                 : Laclede's LAN : , "sv"      : Hostname for server.`,
+		`mp_maxrounds                             : 30       : , "sv", "nf"     : max number of rounds to play before server changes maps`,
+		`sv_cheats                                : 0        : , "sv", "nf", "rep" : Allow cheats on server`,
+		`--------------`,
+		`  4 total convars/concommands`,
+	}, "\n") + "\n"
+
+	sut := NewObserver()
+	sut.AddCvarWatcher("mp_maxrounds", "mp_not_a_cvar", "changelevel")
+
+	if err := sut.ValidateCvar("mp_not_a_cvar", "1"); err != nil {
+		t.Errorf("Cvars should be valid until cvarlist is received not %v.", err)
+	}
+
+	sut.Read(strings.NewReader(stream))
+	sut.Wait()
+
+	if v, ok := sut.TryCvarAsInt("mp_maxrounds", 0); !ok || v != 30 {
+		t.Errorf("Expected the watched cvar to take its value from cvarlist not %d.", v)
+	}
+
+	snapshot := sut.CvarSnapshot()
+	if len(snapshot) != 3 || snapshot[0].Name != "hostname" || snapshot[2].Name != "sv_cheats" || !snapshot[2].HasFlag("REP") || snapshot[2].HasFlag("cheat") {
+		t.Errorf("Unexpected cvar snapshot %+v.", snapshot)
+	}
+
+	if info, ok := sut.CvarInfo("changelevel"); !ok || !info.IsCommand {
+		t.Errorf("Expected changelevel to be listed as a command not %+v.", info)
+	}
+
+	if unknown := sut.UnknownCvarWatchers(); strings.Join(unknown, ",") != "changelevel,mp_not_a_cvar" {
+		t.Errorf("Expected changelevel and mp_not_a_cvar to be unknown watchers not %q.", unknown)
+	}
+
+	validCases := [][2]string{{"mp_maxrounds", "16"}, {"hostname", "Laclede's LAN 2"}, {"hostname", "42"}}
+	for _, test := range validCases {
+		if err := sut.ValidateCvar(test[0], test[1]); err != nil {
+			t.Errorf("Setting %q to %q should have been valid: %v", test[0], test[1], err)
+		}
+	}
+
+	invalidCases := [][2]string{{"mp_maxrounds", "lots"}, {"mp_not_a_cvar", "1"}, {"changelevel", "de_dust2"}}
+	for _, test := range invalidCases {
+		if err := sut.ValidateCvar(test[0], test[1]); err == nil {
+			t.Errorf("Setting %q to %q should NOT have been valid.", test[0], test[1])
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package srcds
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// CvarDrift is reported when a locked cvar is observed with a value other than the one it is locked to
+type CvarDrift struct {
+	Name      string
+	Required  string
+	Observed  string
+	At        time.Time
+	Source    CvarSource
+	ChangedBy string // rcon address that issued the change, if the log revealed it
+	Command   string // command that caused the change, if the log revealed it
+	Enforced  bool   // true if the required value was sent to the server
+}
+
+// cvarLocks are the cvars a server must keep at their required values
+type cvarLocks struct {
+	commands map[string]rconCommand // most recent rcon command that set each locked cvar
+	enforce  bool
+	handlers []func(CvarDrift)
+	mux      sync.Mutex
+	required map[string]string
+}
+
+// LockCvar requires the cvar to keep the specified value and queries its current value; drift is reported and, unless
+// disabled, the value is re-set
+func (s *Server) LockCvar(name, value string) {
+	name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+	if len(name) == 0 {
+		return
+	}
+
+	if err := s.ValidateCvar(name, value); err != nil {
+		log.Warn().Err(err).Msgf("Locking cvar %q to %q", name, value)
+	}
+
+	s.locks.mux.Lock()
+	if s.locks.required == nil {
+		s.locks.required = make(map[string]string)
+	}
+	s.locks.required[name] = value
+	s.locks.mux.Unlock()
+
+	s.AddCvarWatcher(name)
+
+	// Ask for the current value so drift that predates the lock is enforced
+	go s.QueueCommand(name)
+}
+
+// LockCvars requires each of the cvars to keep the specified value
+func (s *Server) LockCvars(cvars map[string]string) {
+	for name, value := range cvars {
+		s.LockCvar(name, value)
+	}
+}
+
+// UnlockCvar allows the cvar to change freely; it remains watched
+func (s *Server) UnlockCvar(name string) {
+	s.locks.mux.Lock()
+	delete(s.locks.required, strings.TrimSpace(name))
+	s.locks.mux.Unlock()
+}
+
+// LockedCvars returns the locked cvars and their required values
+func (s *Server) LockedCvars() map[string]string {
+	s.locks.mux.Lock()
+	defer s.locks.mux.Unlock()
+
+	r := make(map[string]string, len(s.locks.required))
+	for name, value := range s.locks.required {
+		r[name] = value
+	}
+
+	return r
+}
+
+// OnCvarDrift registers a handler to be called whenever a locked cvar is observed with another value
+func (s *Server) OnCvarDrift(h func(CvarDrift)) {
+	if h == nil {
+		return
+	}
+
+	s.locks.mux.Lock()
+	s.locks.handlers = append(s.locks.handlers, h)
+	s.locks.mux.Unlock()
+}
+
+// SetCvarLockEnforcement determines if drifting cvars are re-set to their required values; enabled by default
+func (s *Server) SetCvarLockEnforcement(enforce bool) {
+	s.locks.mux.Lock()
+	s.locks.enforce = enforce
+	s.locks.mux.Unlock()
+}
+
+// checkCvarLock reports (and corrects) a locked cvar that changed to something other than its required value
+func (s *Server) checkCvarLock(change CvarChange) {
+	if change.Source == CvarSourceSeed {
+		return
+	}
+
+	s.locks.mux.Lock()
+	required, locked := s.locks.required[change.Name]
+	if !locked || cvarValuesEqual(required, change.NewValue) {
+		s.locks.mux.Unlock()
+		return
+	}
+
+	cmd := s.locks.commands[change.Name]
+	delete(s.locks.commands, change.Name)
+
+	drift := CvarDrift{
+		Name:      change.Name,
+		Required:  required,
+		Observed:  change.NewValue,
+		At:        change.At,
+		Source:    change.Source,
+		ChangedBy: cmd.address,
+		Command:   cmd.command,
+		Enforced:  s.locks.enforce,
+	}
+	handlers := s.locks.handlers
+	s.locks.mux.Unlock()
+
+	l := log.Warn().Str("cvar", drift.Name).Str("required", drift.Required).Str("observed", drift.Observed)
+	if len(drift.ChangedBy) > 0 {
+		l = l.Str("changed_by", drift.ChangedBy).Str("command", drift.Command)
+	}
+	l.Msgf("Locked cvar %q drifted from its required value", drift.Name)
+
+	if drift.Enforced {
+		// Sent asynchronously so a busy command queue never stalls the observer
+		go s.QueueCommand(ConfigCommand{Name: drift.Name, Args: []string{drift.Required}}.String())
+	}
+
+	for _, h := range handlers {
+		h(drift)
+	}
+}
+
+// recordRconCommand remembers rcon commands that set locked cvars so drift can be attributed to whoever issued them
+func (s *Server) recordRconCommand(le LogEntry, _ []string) ParseAction {
+	rc, ok := parseRconCommand(le)
+	if !ok {
+		return ParseContinue
+	}
+
+	s.locks.mux.Lock()
+	defer s.locks.mux.Unlock()
+
+	for _, cmd := range strings.Split(rc.command, ";") {
+		fields := strings.Fields(cmd)
+		if len(fields) > 0 && (fields[0] == "sm_cvar" || fields[0] == "rcon") {
+			fields = fields[1:]
+		}
+
+		// Only assignments are of interest; sending a cvar's name merely echoes its value
+		if len(fields) < 2 {
+			continue
+		}
+
+		if _, locked := s.locks.required[fields[0]]; locked {
+			if s.locks.commands == nil {
+				s.locks.commands = make(map[string]rconCommand)
+			}
+
+			s.locks.commands[fields[0]] = rconCommand{address: rc.address, command: strings.TrimSpace(cmd)}
+		}
+	}
+
+	return ParseContinue
+}
+
+// cvarValuesEqual compares cvar values numerically when both are numbers (e.g. "1" and "1.000")
+func cvarValuesEqual(a, b string) bool {
+	a, b = strings.Trim(strings.TrimSpace(a), `"`), strings.Trim(strings.TrimSpace(b), `"`)
+	if a == b {
+		return true
+	}
+
+	af, errA := strconv.ParseFloat(a, 64)
+	bf, errB := strconv.ParseFloat(b, 64)
+
+	return errA == nil && errB == nil && af == bf
+}
@@ -0,0 +1,122 @@
+package srcds
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_Server_LockCvar(t *testing.T) {
+	sut := NewServer()
+	sut.LockCvars(map[string]string{"hostname": "Laclede's LAN", "mp_maxrounds": "30", "sv_cheats": "0"})
+
+	actual := []CvarDrift{}
+	sut.OnCvarDrift(func(d CvarDrift) {
+		actual = append(actual, d)
+	})
+
+	stream := strings.Join([]string{
+		`L 01/01/2000 - 10:00:00: server_cvar: "mp_maxrounds" "30"`,
+		`L 01/01/2000 - 10:00:01: server_cvar: "sv_cheats" "0.000000"`,
+		`L 01/01/2000 - 10:01:00: rcon from "192.168.1.5:51234": command "sm_cvar mp_maxrounds 16"`,
+		`L 01/01/2000 - 10:01:00: server_cvar: "mp_maxrounds" "16"`,
+		`L 01/01/2000 - 10:02:00: server_cvar: "sv_cheats" "1"`,
+		`L 01/01/2000 - 10:03:00: server_cvar: "hostname" "pwned"`,
+	}, "\n") + "\n"
+
+	sut.Observer.Read(strings.NewReader(stream))
+	sut.Observer.Wait()
+
+	if len(actual) != 3 {
+		t.Fatalf("Expected 3 drifts not %+v.", actual)
+	}
+
+	if d := actual[0]; d.Name != "mp_maxrounds" || d.Observed != "16" || d.Required != "30" || d.ChangedBy != "192.168.1.5:51234" ||
+		d.Command != "sm_cvar mp_maxrounds 16" || !d.Enforced || d.Source != CvarSourceLog {
+		t.Errorf("Unexpected drift %+v.", d)
+	}
+
+	if d := actual[1]; d.Name != "sv_cheats" || d.ChangedBy != "" {
+		t.Errorf("Unexpected drift %+v.", d)
+	}
+
+	// Each lock queries the current value as well
+	sent := map[string]bool{}
+	for i := 0; i < 6; i++ {
+		sent[<-sut.cmdIn] = true
+	}
+
+	if !sent[`hostname`] || !sent[`mp_maxrounds`] || !sent[`sv_cheats`] {
+		t.Errorf("Expected the locked cvars to be queried not %v.", sent)
+	}
+
+	if !sent[`mp_maxrounds 30`] || !sent[`sv_cheats 0`] || !sent[`hostname "Laclede's LAN"`] {
+		t.Errorf("Expected the locked values to be re-sent not %v.", sent)
+	}
+}
+
+func Test_Server_LockCvar_existingDrift(t *testing.T) {
+	sut := NewServer()
+
+	actual := make(chan CvarDrift, 1)
+	sut.OnCvarDrift(func(d CvarDrift) {
+		actual <- d
+	})
+
+	sut.LockCvar("sv_cheats", "0")
+	if cmd := <-sut.cmdIn; cmd != "sv_cheats" {
+		t.Fatalf("Expected the locked cvar to be queried not %q.", cmd)
+	}
+
+	// SRCDS answers with the value it had before the lock
+	sut.processMessage(`"sv_cheats" = "1" ( def. "0" ) notify replicated                    - Allow cheats on server`, nil)
+
+	select {
+	case d := <-actual:
+		if d.Name != "sv_cheats" || d.Observed != "1" || !d.Enforced || d.Source != CvarSourceConsole {
+			t.Errorf("Unexpected drift %+v.", d)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the existing drift to be reported.")
+	}
+
+	if cmd := <-sut.cmdIn; cmd != "sv_cheats 0" {
+		t.Errorf("Expected the locked value to be re-sent not %q.", cmd)
+	}
+}
+
+func Test_Server_QueueCommand(t *testing.T) {
+	sut := NewServer()
+	for i := 0; i < cap(sut.cmdIn); i++ {
+		if !sut.QueueCommand("status") {
+			t.Fatal("Expected the command to be queued while the server is running.")
+		}
+	}
+
+	sut.health.exit(time.Now(), nil)
+
+	done := make(chan bool)
+	go func() { done <- sut.QueueCommand("status") }()
+
+	select {
+	case queued := <-done:
+		if queued {
+			t.Error("Expected the command NOT to be queued once the server stopped.")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Queueing a command blocked after the server stopped.")
+	}
+}
+
+func Test_cvarValuesEqual(t *testing.T) {
+	validCases := [][2]string{{"1", "1.000000"}, {"de_dust2", "de_dust2"}, {`"0"`, "0"}}
+	for _, test := range validCases {
+		if !cvarValuesEqual(test[0], test[1]) {
+			t.Errorf("Expected %q and %q to be equal.", test[0], test[1])
+		}
+	}
+
+	if cvarValuesEqual("1", "10") {
+		t.Error("Expected 1 and 10 NOT to be equal.")
+	}
+}
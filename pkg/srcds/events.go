@@ -0,0 +1,64 @@
+package srcds
+
+import (
+	"sync"
+	"time"
+)
+
+// Event is emitted by a game's observer whenever a noteworthy game moment is detected
+type Event interface {
+	// Time of the log entry that caused the event
+	Time() time.Time
+}
+
+// EventTime is embedded by events to record the time of the log entry that caused them
+type EventTime struct {
+	At time.Time
+}
+
+// Time of the log entry that caused the event
+func (e EventTime) Time() time.Time { return e.At }
+
+// EventHandler receives events emitted by an observer
+type EventHandler func(Event)
+
+// EventQueue holds the events emitted while a log entry is processed until they can be dispatched; events are emitted
+// while the observer's lock is held but must be dispatched after it has been released so handlers can query the observer
+type EventQueue struct {
+	handlers []EventHandler
+	mux      sync.Mutex
+	pending  []Event
+}
+
+// OnEvent registers a handler to be called for every dispatched event; handlers are called in the order they were
+// registered
+func (q *EventQueue) OnEvent(h EventHandler) {
+	if h == nil {
+		return
+	}
+
+	q.mux.Lock()
+	q.handlers = append(q.handlers, h)
+	q.mux.Unlock()
+}
+
+// Emit queues an event to be dispatched once the current log entry has been processed
+func (q *EventQueue) Emit(e Event) {
+	q.mux.Lock()
+	q.pending = append(q.pending, e)
+	q.mux.Unlock()
+}
+
+// Dispatch sends the queued events to every handler; must not be called while holding the observer's lock
+func (q *EventQueue) Dispatch() {
+	q.mux.Lock()
+	events, handlers := q.pending, q.handlers
+	q.pending = nil
+	q.mux.Unlock()
+
+	for _, e := range events {
+		for _, h := range handlers {
+			h(e)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package srcds
+
+import (
+	"testing"
+	"time"
+)
+
+type testEvent struct {
+	EventTime
+	Name string
+}
+
+func Test_EventQueue(t *testing.T) {
+	at := time.Date(2020, 10, 3, 14, 0, 0, 0, time.UTC)
+	sut := EventQueue{}
+
+	sut.Emit(testEvent{EventTime{At: at}, "before any handler"})
+	sut.Dispatch()
+
+	actual := []string{}
+	sut.OnEvent(nil)
+	sut.OnEvent(func(e Event) {
+		if !e.Time().Equal(at) {
+			t.Errorf("Expected the event to have occurred at %v not %v.", at, e.Time())
+		}
+
+		actual = append(actual, "first "+e.(testEvent).Name)
+	})
+	sut.OnEvent(func(e Event) { actual = append(actual, "second "+e.(testEvent).Name) })
+
+	sut.Emit(testEvent{EventTime{At: at}, "a"})
+	sut.Emit(testEvent{EventTime{At: at}, "b"})
+
+	if len(actual) != 0 {
+		t.Fatalf("Events should NOT be sent before they are dispatched: %v.", actual)
+	}
+
+	sut.Dispatch()
+	sut.Dispatch()
+
+	expected := []string{"first a", "second a", "first b", "second b"}
+	if len(actual) != len(expected) {
+		t.Fatalf("Expected events %v not %v.", expected, actual)
+	}
+
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Fatalf("Expected events %v not %v.", expected, actual)
+		}
+	}
+}
@@ -0,0 +1,344 @@
+package srcds
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// probePrefix starts the text echoed by console probes
+const probePrefix = "sourceseer_probe_"
+
+// HealthThresholds determine when a SRCDS instance is considered unhealthy
+type HealthThresholds struct {
+	Silence       time.Duration // longest SRCDS may go without output; 0 to never consider silence unhealthy
+	ProbeInterval time.Duration // how often the console is probed and health is checked
+	ProbeTimeout  time.Duration // longest a probe may go unanswered
+}
+
+// DefaultHealthThresholds are suitable for a server that is probed; probes cause output so a silent server is stuck
+func DefaultHealthThresholds() HealthThresholds {
+	return HealthThresholds{
+		Silence:       2 * time.Minute,
+		ProbeInterval: 30 * time.Second,
+		ProbeTimeout:  10 * time.Second,
+	}
+}
+
+// Health is the state of a SRCDS instance at a point in time
+type Health struct {
+	Healthy           bool
+	Problems          []string
+	CheckedAt         time.Time
+	Running           bool
+	Started           time.Time
+	Exited            time.Time
+	ExitError         string
+	LastOutput        time.Time
+	Map               string
+	MapLoaded         bool
+	ProbeSent         time.Time
+	ProbeAnswered     time.Time
+	ConsoleResponsive bool
+}
+
+// healthTracker records the liveness of a SRCDS instance
+type healthTracker struct {
+	done          chan struct{} // closed once the process exits
+	exitErr       error
+	exited        time.Time
+	lastOutput    time.Time
+	mapLoaded     bool
+	mapName       string
+	mux           sync.Mutex
+	probeAnswered time.Time
+	probeSent     time.Time
+	probeSeq      uint64
+	probeToken    string
+	started       time.Time
+	thresholds    HealthThresholds
+}
+
+func newHealthTracker() *healthTracker {
+	return &healthTracker{done: make(chan struct{}), thresholds: DefaultHealthThresholds()}
+}
+
+func (h *healthTracker) start(at time.Time) {
+	h.mux.Lock()
+	h.started, h.lastOutput = at, at
+	h.mux.Unlock()
+}
+
+func (h *healthTracker) exit(at time.Time, err error) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	if !h.exited.IsZero() {
+		return
+	}
+
+	h.exited, h.exitErr, h.mapLoaded = at, err, false
+	close(h.done)
+}
+
+func (h *healthTracker) output(at time.Time) {
+	h.mux.Lock()
+	h.lastOutput = at
+	h.mux.Unlock()
+}
+
+// mapLoading records a map change; the map isn't loaded until it has started
+func (h *healthTracker) mapLoading(mapName string) {
+	h.mux.Lock()
+	h.mapName, h.mapLoaded = mapName, false
+	h.mux.Unlock()
+}
+
+func (h *healthTracker) mapStarted(mapName string) {
+	h.mux.Lock()
+	h.mapName, h.mapLoaded = mapName, true
+	h.mux.Unlock()
+}
+
+// nextProbe returns the text the next console probe echoes
+func (h *healthTracker) nextProbe(at time.Time) string {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	h.probeSeq++
+	h.probeToken = fmt.Sprintf("%v%d", probePrefix, h.probeSeq)
+	h.probeSent = at
+
+	return h.probeToken
+}
+
+// probePending determines if the most recent probe has yet to be answered
+func (h *healthTracker) probePending() bool {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	return !h.probeSent.IsZero() && h.probeAnswered.Before(h.probeSent)
+}
+
+// consoleLine records the answer to the most recent probe
+func (h *healthTracker) consoleLine(line ConsoleLine) {
+	if !strings.Contains(line.Text, probePrefix) {
+		return
+	}
+
+	h.mux.Lock()
+	if len(h.probeToken) > 0 && strings.Contains(line.Text, h.probeToken) {
+		h.probeAnswered = time.Now()
+	}
+	h.mux.Unlock()
+}
+
+// check determines the health of the SRCDS instance as of the specified time
+func (h *healthTracker) check(at time.Time) Health {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	r := Health{
+		CheckedAt:     at,
+		Running:       !h.started.IsZero() && h.exited.IsZero(),
+		Started:       h.started,
+		Exited:        h.exited,
+		LastOutput:    h.lastOutput,
+		Map:           h.mapName,
+		MapLoaded:     h.mapLoaded,
+		ProbeSent:     h.probeSent,
+		ProbeAnswered: h.probeAnswered,
+	}
+
+	if h.exitErr != nil {
+		r.ExitError = h.exitErr.Error()
+	}
+
+	probePending := !h.probeSent.IsZero() && h.probeAnswered.Before(h.probeSent)
+	probeOverdue := probePending && at.Sub(h.probeSent) > h.thresholds.ProbeTimeout
+	r.ConsoleResponsive = !h.probeAnswered.IsZero() && !probeOverdue
+
+	switch {
+	case h.started.IsZero():
+		r.Problems = append(r.Problems, "SRCDS hasn't been started")
+	case !h.exited.IsZero():
+		r.Problems = append(r.Problems, "SRCDS has exited")
+	default:
+		if silence := at.Sub(h.lastOutput); h.thresholds.Silence > 0 && silence > h.thresholds.Silence {
+			r.Problems = append(r.Problems, fmt.Sprintf("SRCDS has had no output for %v", silence.Round(time.Second)))
+		}
+
+		if !h.mapLoaded {
+			r.Problems = append(r.Problems, "No map is loaded")
+		}
+
+		if probeOverdue {
+			r.Problems = append(r.Problems, fmt.Sprintf("Console hasn't answered a probe sent %v ago", at.Sub(h.probeSent).Round(time.Second)))
+		}
+	}
+
+	r.Healthy = len(r.Problems) == 0
+
+	return r
+}
+
+// recordMapStatus is a LineParser handler (of mapStatusRegex) tracking whether a map is loaded
+func (s *Server) recordMapStatus(_ LogEntry, submatches []string) ParseAction {
+	if submatches[1] == "Started" {
+		s.health.mapStarted(submatches[2])
+	} else {
+		s.health.mapLoading(submatches[2])
+	}
+
+	return ParseContinue
+}
+
+// Health returns the current health of the SRCDS instance
+func (s *Server) Health() Health {
+	return s.health.check(time.Now())
+}
+
+// MonitorHealth probes the console and checks the health of the SRCDS instance at every probe interval until it exits;
+// the handler (if any) is called with the result of every check, including a final check once SRCDS exits
+func (s *Server) MonitorHealth(t HealthThresholds, h func(Health)) {
+	if t.ProbeInterval <= 0 {
+		t.ProbeInterval = DefaultHealthThresholds().ProbeInterval
+	}
+
+	if t.ProbeTimeout <= 0 {
+		t.ProbeTimeout = DefaultHealthThresholds().ProbeTimeout
+	}
+
+	s.health.mux.Lock()
+	s.health.thresholds = t
+	s.health.mux.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(t.ProbeInterval)
+		defer ticker.Stop()
+
+		wasHealthy := false // a server is expected to be unhealthy while it starts
+		for {
+			health := s.Health()
+			if health.Healthy != wasHealthy {
+				if health.Healthy {
+					log.Info().Msg("SRCDS is healthy")
+				} else {
+					log.Warn().Strs("problems", health.Problems).Msg("SRCDS is unhealthy")
+				}
+				wasHealthy = health.Healthy
+			}
+
+			if h != nil {
+				h(health)
+			}
+
+			select {
+			case <-s.health.done:
+				if h != nil {
+					h(s.Health())
+				}
+				return
+			case <-ticker.C:
+			}
+
+			// An unanswered probe isn't replaced, so it stays overdue until the console answers
+			if s.Health().Running && !s.health.probePending() {
+				s.QueueCommand("echo " + s.health.nextProbe(time.Now()))
+			}
+		}
+	}()
+}
+
+// HealthHandler serves the health of the SRCDS instance as JSON; the status is 200 if healthy otherwise 503
+func (s *Server) HealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		health := s.Health()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+
+		if health.Healthy {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+
+		if r.Method != http.MethodHead {
+			writeHealth(w, health)
+		}
+	})
+}
+
+// WriteHealthFile writes the health as JSON to the file; the file is replaced atomically so readers never see a partial
+// write
+func WriteHealthFile(path string, health Health) error {
+	f, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("Couldn't create health file: %w", err)
+	}
+
+	if err := writeHealth(f, health); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return fmt.Errorf("Couldn't write health file: %w", err)
+	}
+
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return fmt.Errorf("Couldn't write health file: %w", err)
+	}
+
+	if err := os.Rename(f.Name(), path); err != nil {
+		os.Remove(f.Name())
+		return fmt.Errorf("Couldn't replace health file: %w", err)
+	}
+
+	return nil
+}
+
+// ReadHealthFile reads the health written by WriteHealthFile
+func ReadHealthFile(path string) (Health, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Health{}, fmt.Errorf("Couldn't read health file: %w", err)
+	}
+
+	var r Health
+	if err := json.Unmarshal(b, &r); err != nil {
+		return Health{}, fmt.Errorf("Couldn't parse health file %q: %w", path, err)
+	}
+
+	return r, nil
+}
+
+func writeHealth(w io.Writer, health Health) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(health)
+}
+
+// outputRecorder records the time SRCDS last produced output
+type outputRecorder struct {
+	r      io.Reader
+	health *healthTracker
+}
+
+func (o outputRecorder) Read(p []byte) (int, error) {
+	n, err := o.r.Read(p)
+	if n > 0 {
+		o.health.output(time.Now())
+	}
+
+	return n, err
+}
@@ -0,0 +1,189 @@
+package srcds
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_Server_Health(t *testing.T) {
+	sut := NewServer()
+
+	if h := sut.Health(); h.Healthy || h.Running {
+		t.Errorf("A server that hasn't started should NOT be healthy: %+v.", h)
+	}
+
+	started := time.Now()
+	sut.health.start(started)
+	token := sut.health.nextProbe(started)
+
+	stream := strings.Join([]string{
+		`L 01/01/2000 - 10:00:00: Loading map "de_nuke"`,
+		`L 01/01/2000 - 10:00:05: Started map "de_nuke" (CRC "-1787457339")`,
+		token,
+	}, "\n") + "\n"
+
+	sut.Observer.Read(strings.NewReader(stream))
+	sut.Observer.Wait()
+
+	h := sut.health.check(started.Add(time.Minute))
+	if !h.Healthy || !h.Running || !h.MapLoaded || h.Map != "de_nuke" || !h.ConsoleResponsive {
+		t.Errorf("Expected a healthy server not %+v.", h)
+	}
+
+	// An unanswered probe becomes overdue
+	sut.health.nextProbe(started.Add(time.Minute))
+	if h := sut.health.check(started.Add(time.Minute + 5*time.Second)); !h.Healthy {
+		t.Errorf("A probe within its timeout should NOT be unhealthy: %+v.", h)
+	}
+
+	if h := sut.health.check(started.Add(time.Minute + 15*time.Second)); h.Healthy || h.ConsoleResponsive || len(h.Problems) != 1 {
+		t.Errorf("An overdue probe should be unhealthy: %+v.", h)
+	}
+
+	// Silence is unhealthy
+	if h := sut.health.check(started.Add(time.Hour)); h.Healthy || len(h.Problems) != 2 {
+		t.Errorf("A silent server should be unhealthy: %+v.", h)
+	}
+
+	sut.health.mapLoading("de_inferno")
+	if h := sut.health.check(started); h.MapLoaded || h.Map != "de_inferno" || h.Healthy {
+		t.Errorf("A server changing maps should NOT be healthy: %+v.", h)
+	}
+
+	sut.health.exit(started.Add(2*time.Hour), errors.New("exit status 1"))
+	if h := sut.Health(); h.Healthy || h.Running || h.ExitError != "exit status 1" {
+		t.Errorf("An exited server should NOT be healthy: %+v.", h)
+	}
+
+	select {
+	case <-sut.health.done:
+	default:
+		t.Error("Exiting should have signaled the monitor.")
+	}
+}
+
+func Test_Server_Health_exitWithoutOutput(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Requires a POSIX shell")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sut := NewServer()
+	if err := sut.SetExecContext(ctx, "sh", "-c", "true"); err != nil {
+		t.Fatalf("Unexpected error setting the exec: %v", err)
+	}
+
+	c, err := sut.Listen()
+	if err != nil {
+		t.Fatalf("Unexpected error starting the server: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		for range c {
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("The log stream should close once a server that output nothing exits.")
+	}
+
+	if h := sut.Health(); h.Running || h.Exited.IsZero() {
+		t.Errorf("Expected the server to have exited not %+v.", h)
+	}
+}
+
+func Test_Server_MonitorHealth_exitWhileProbing(t *testing.T) {
+	sut := NewServer()
+	sut.health.start(time.Now())
+
+	// Nothing reads the console's commands, as when the standard in writer stopped before SRCDS exited
+	for i := 0; i < cap(sut.cmdIn); i++ {
+		sut.cmdIn <- "status"
+	}
+
+	exited := make(chan Health, 1)
+	sut.MonitorHealth(HealthThresholds{Silence: time.Hour, ProbeInterval: 10 * time.Millisecond, ProbeTimeout: time.Minute}, func(h Health) {
+		if !h.Running {
+			select {
+			case exited <- h:
+			default:
+			}
+		}
+	})
+
+	time.Sleep(50 * time.Millisecond)
+	sut.health.exit(time.Now(), nil)
+
+	select {
+	case h := <-exited:
+		if h.Exited.IsZero() {
+			t.Errorf("Expected the final health to have an exit time not %+v.", h)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("The monitor should report the exit even while a probe couldn't be sent.")
+	}
+}
+
+func Test_Server_HealthHandler(t *testing.T) {
+	sut := NewServer()
+
+	rec := httptest.NewRecorder()
+	sut.HealthHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusServiceUnavailable || !strings.Contains(rec.Body.String(), `"Healthy": false`) {
+		t.Errorf("Expected an unhealthy response not %d %q.", rec.Code, rec.Body.String())
+	}
+
+	sut.health.start(time.Now())
+	sut.health.mapStarted("de_nuke")
+
+	rec = httptest.NewRecorder()
+	sut.HealthHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodHead, "/healthz", nil))
+	if rec.Code != http.StatusOK || rec.Body.Len() != 0 {
+		t.Errorf("Expected a healthy response without a body not %d %q.", rec.Code, rec.Body.String())
+	}
+}
+
+func Test_WriteHealthFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "health")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "health.json")
+	expected := Health{Healthy: true, CheckedAt: time.Date(2020, 10, 3, 14, 0, 0, 0, time.UTC), Map: "de_nuke", MapLoaded: true}
+
+	for i := 0; i < 2; i++ {
+		if err := WriteHealthFile(path, expected); err != nil {
+			t.Fatalf("Health file should have been written: %v", err)
+		}
+	}
+
+	actual, err := ReadHealthFile(path)
+	if err != nil {
+		t.Fatalf("Health file should have been read: %v", err)
+	}
+
+	if actual.Healthy != expected.Healthy || !actual.CheckedAt.Equal(expected.CheckedAt) || actual.Map != expected.Map {
+		t.Errorf("Expected health %+v not %+v.", expected, actual)
+	}
+
+	if files, _ := ioutil.ReadDir(dir); len(files) != 1 {
+		t.Errorf("Temporary health files should have been cleaned up; found %d files.", len(files))
+	}
+}
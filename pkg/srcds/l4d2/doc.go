@@ -0,0 +1,5 @@
+/*
+Package l4d2 provides functionality for tailing, parsing, and interpreting <a href="https://www.l4d.com/">Left 4 Dead 2</a> dedicated server logging for versus
+matches.
+*/
+package l4d2
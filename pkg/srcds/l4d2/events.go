@@ -0,0 +1,16 @@
+package l4d2
+
+import (
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+)
+
+// OnEvent registers a handler to be called for every event the L4D2 observer emits; handlers are called in the order they
+// were registered
+func (o *Observer) OnEvent(h srcds.EventHandler) {
+	o.game.events.OnEvent(h)
+}
+
+// emit queues an event to be dispatched once the current log entry has been processed
+func (g *gameInfo) emit(e srcds.Event) {
+	g.events.Emit(e)
+}
@@ -0,0 +1,218 @@
+package l4d2
+
+import (
+	"time"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+	"github.com/rs/zerolog/log"
+)
+
+// affiliation represents the side a player is currently playing; versus teams swap sides every round
+type affiliation string
+
+const (
+	unassigned affiliation = "Unassigned"
+	survivor   affiliation = "Survivor"
+	infected   affiliation = "Infected"
+	spectator  affiliation = "Spectator"
+)
+
+// versusTeam represents one of the two teams competing in versus; a team keeps its players as it swaps sides
+type versusTeam string
+
+const (
+	noTeam versusTeam = ""
+	teamA  versusTeam = "A" // the team playing as the survivors in the first round observed
+	teamB  versusTeam = "B"
+)
+
+func (t versusTeam) opponent() versusTeam {
+	switch t {
+	case teamA:
+		return teamB
+	case teamB:
+		return teamA
+	default:
+		return noTeam
+	}
+}
+
+// matchInfo contains statistics about a campaign played in versus
+type matchInfo struct {
+	campaign string
+	chapters []chapterInfo
+	ended    time.Time
+	players  map[string]*playerInfo // keyed by SteamID
+	started  time.Time
+}
+
+// chapterInfo contains statistics about a chapter (map) of a campaign; each team plays it once as the survivors
+type chapterInfo struct {
+	current   roundInfo
+	mapName   string
+	roundLive bool
+	rounds    []roundInfo
+}
+
+// roundInfo contains statistics about one half of a chapter
+type roundInfo struct {
+	ended     time.Time
+	score     int
+	scored    bool
+	started   time.Time
+	survivors versusTeam
+}
+
+type playerInfo struct {
+	affiliation affiliation
+	team        versusTeam
+	username    string
+}
+
+type gameInfo struct {
+	chapter   chapter
+	events    srcds.EventQueue
+	matches   []matchInfo
+	survivors versusTeam // the team most recently seen playing as the survivors; empty until the first round
+}
+
+// currentSurvivors returns the team most recently seen playing as the survivors; team A is assumed before any round
+func (g *gameInfo) currentSurvivors() versusTeam {
+	if g.survivors == noTeam {
+		return teamA
+	}
+
+	return g.survivors
+}
+
+// currentMatch returns the current match, starting one if none has been observed
+func (g *gameInfo) currentMatch(at time.Time) *matchInfo {
+	if len(g.matches) == 0 {
+		g.nextMatch(at)
+	}
+
+	return &g.matches[len(g.matches)-1]
+}
+
+// currentChapter returns the chapter being played in the current match, starting one if none has been observed
+func (g *gameInfo) currentChapter(at time.Time) *chapterInfo {
+	m := g.currentMatch(at)
+	if len(m.chapters) == 0 {
+		m.chapters = append(m.chapters, chapterInfo{mapName: g.chapter.mapName})
+	}
+
+	return &m.chapters[len(m.chapters)-1]
+}
+
+// nextMatch will end the current match and start the next as a new campaign begins; a current match without any
+// completed rounds is reused. Players keep their team.
+func (g *gameInfo) nextMatch(at time.Time) {
+	next := matchInfo{
+		campaign: g.chapter.campaign,
+		players:  make(map[string]*playerInfo),
+		started:  at,
+	}
+
+	if n := len(g.matches); n > 0 {
+		for id, p := range g.matches[n-1].players {
+			next.players[id] = &playerInfo{affiliation: p.affiliation, team: p.team, username: p.username}
+		}
+	}
+
+	if n := len(g.matches); n > 0 && g.matches[n-1].roundsPlayed() == 0 {
+		g.matches[n-1] = next
+		return
+	}
+
+	if n := len(g.matches); n > 0 && g.matches[n-1].ended.IsZero() {
+		g.matches[n-1].ended = at
+	}
+
+	g.matches = append(g.matches, next)
+	log.Info().Msgf("Match %02d starting on campaign %q", len(g.matches), g.chapter.campaign)
+}
+
+// player returns the statistics of a player in the current match; players are placed on a versus team by the side
+// they are first seen playing
+func (m *matchInfo) player(c srcds.Client, survivors versusTeam) *playerInfo {
+	p, found := m.players[c.SteamID]
+	if !found {
+		p = &playerInfo{}
+		m.players[c.SteamID] = p
+	}
+
+	p.username = c.Username
+	if aff, ok := parseAffiliation(c.Affiliation); ok && aff != unassigned {
+		p.affiliation = aff
+	}
+
+	p.assignTeam(survivors)
+
+	return p
+}
+
+// assignTeam places a player without a versus team on the team currently playing their side
+func (p *playerInfo) assignTeam(survivors versusTeam) {
+	if p.team != noTeam {
+		return
+	}
+
+	switch p.affiliation {
+	case survivor:
+		p.team = survivors
+	case infected:
+		p.team = survivors.opponent()
+	}
+}
+
+func (m *matchInfo) roundsPlayed() (r int) {
+	for _, c := range m.chapters {
+		r += len(c.rounds)
+	}
+
+	return r
+}
+
+// scores returns the total score of each team across the match's chapters
+func (m *matchInfo) scores() (a, b int) {
+	add := func(r roundInfo) {
+		switch r.survivors {
+		case teamA:
+			a += r.score
+		case teamB:
+			b += r.score
+		}
+	}
+
+	for _, c := range m.chapters {
+		for _, r := range c.rounds {
+			add(r)
+		}
+
+		if c.roundLive {
+			add(c.current)
+		}
+	}
+
+	return a, b
+}
+
+// survivingTeam determines which versus team is playing as the survivors from the majority of the players on the
+// survivor side; false if no player is known to be a survivor
+func (m *matchInfo) survivingTeam() (versusTeam, bool) {
+	count := map[versusTeam]int{}
+	for _, p := range m.players {
+		if p.affiliation == survivor && p.team != noTeam {
+			count[p.team]++
+		}
+	}
+
+	switch {
+	case count[teamA] == 0 && count[teamB] == 0:
+		return noTeam, false
+	case count[teamB] > count[teamA]:
+		return teamB, true
+	default:
+		return teamA, true
+	}
+}
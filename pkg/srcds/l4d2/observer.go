@@ -0,0 +1,300 @@
+package l4d2
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+	"github.com/rs/zerolog/log"
+)
+
+// ChapterChanged is emitted when the server loads the next chapter (map) of a campaign, or a new campaign
+type ChapterChanged struct {
+	srcds.EventTime
+	Match      int
+	Campaign   string
+	Chapter    int // position within the match, starting at 1
+	Map        string
+	TeamAScore int
+	TeamBScore int
+}
+
+// DistanceScored is emitted when the server reports the survivors' score for a round
+type DistanceScored struct {
+	srcds.EventTime
+	Match      int
+	Chapter    int
+	Half       int
+	Team       string // A or B
+	Score      int
+	TeamAScore int
+	TeamBScore int
+}
+
+// RoundEnded is emitted when one half of a chapter ends
+type RoundEnded struct {
+	srcds.EventTime
+	Match      int
+	Chapter    int
+	Half       int
+	Survivors  string // A or B
+	Score      int    // zero if the server has yet to report the survivors' score
+	TeamAScore int
+	TeamBScore int
+}
+
+// TeamsSwapped is emitted when a round starts with the other team playing as the survivors
+type TeamsSwapped struct {
+	srcds.EventTime
+	Match     int
+	Chapter   int
+	Half      int
+	Survivors string // A or B
+	Infected  string
+}
+
+// NewObserver for observing L4D2 log streams
+func NewObserver() *Observer {
+	o := &Observer{
+		srcdsObserver: srcds.NewObserver(),
+	}
+
+	return o
+}
+
+// Read an L4D2 log output stream
+func (o *Observer) Read(r io.Reader) {
+	o.waitGroup.Add(1)
+	go func() {
+		defer o.waitGroup.Done()
+		for range o.Listen(r) {
+		}
+	}()
+}
+
+// Listen to an L4D2 log output stream; the stream must be drained as the observer blocks while it is full,
+// consumers that may fall behind should Subscribe instead
+func (o *Observer) Listen(r io.Reader) <-chan srcds.LogEntry {
+	logStream := make(chan srcds.LogEntry, 6)
+	o.waitGroup.Add(1)
+
+	go func(l chan<- srcds.LogEntry) {
+		defer close(l)
+		defer o.waitGroup.Done()
+		for le := range o.srcdsObserver.Listen(r) {
+			o.processLogEntry(le)
+			l <- le
+		}
+	}(logStream)
+
+	return logStream
+}
+
+// Wait for the L4D2 observer to exit naturally.
+func (o *Observer) Wait() {
+	o.waitGroup.Wait()
+}
+
+// RegisterParser adds a parser that is offered every log entry before the L4D2 observer processes it; parsers
+// registered while the log stream is being observed are offered the log entries that follow
+func (o *Observer) RegisterParser(p srcds.LineParser) error {
+	return o.srcdsObserver.RegisterParser(p)
+}
+
+// Subscribe to the log stream; subscribers receive each log entry before the L4D2 observer processes it
+func (o *Observer) Subscribe(name string, size int, policy srcds.OverflowPolicy) *srcds.Subscription {
+	return o.srcdsObserver.Subscribe(name, size, policy)
+}
+
+// Observer for watching L4D2 log streams
+type Observer struct {
+	game          gameInfo
+	mux           sync.Mutex
+	srcdsObserver *srcds.Observer
+	waitGroup     sync.WaitGroup
+}
+
+// processLogEntry and apply it to L4D2
+func (o *Observer) processLogEntry(le srcds.LogEntry) {
+	o.mux.Lock()
+	o.applyLogEntry(le)
+	o.mux.Unlock()
+
+	o.game.events.Dispatch()
+}
+
+// applyLogEntry updates the observed game state; the caller must hold the observer's lock
+func (o *Observer) applyLogEntry(le srcds.LogEntry) {
+	if clientLog, ok := srcds.ParseClientLogEntry(le); ok {
+		o.applyClientLogEntry(le.Timestamp, clientLog)
+		return
+	}
+
+	if parseRoundStart(le) {
+		o.startRound(le.Timestamp)
+		return
+	}
+
+	if parseRoundEnd(le) {
+		o.endRound(le.Timestamp)
+		return
+	}
+
+	if m, ok := parseTeamScored(le); ok {
+		o.recordScore(le.Timestamp, m)
+		return
+	}
+
+	if mapName, ok := srcds.ParseLoadingMap(le); ok {
+		o.changeChapter(le.Timestamp, parseChapter(mapName))
+	}
+}
+
+// applyClientLogEntry updates the observed game state from a player's action
+func (o *Observer) applyClientLogEntry(at time.Time, clientLog srcds.ClientLogEntry) {
+	m := o.game.currentMatch(at)
+	p := m.player(clientLog.Client, o.game.currentSurvivors())
+
+	if aff, ok := parseClientJoinedTeam(clientLog); ok {
+		p.affiliation = aff
+		p.assignTeam(o.game.currentSurvivors())
+		log.Info().Str("SteamID", clientLog.Client.SteamID).Msgf("Client %q joined %v (team %v).", clientLog.Client.Username, aff, p.team)
+	}
+}
+
+// changeChapter starts tracking the next chapter; loading a map from another campaign starts the next match
+func (o *Observer) changeChapter(at time.Time, c chapter) {
+	if c.mapName == o.game.chapter.mapName {
+		return
+	}
+
+	previous := o.game.chapter
+	o.game.chapter = c
+
+	if c.campaign != previous.campaign {
+		o.game.nextMatch(at)
+	}
+
+	m := o.game.currentMatch(at)
+	if n := len(m.chapters); n > 0 && len(m.chapters[n-1].rounds) == 0 {
+		m.chapters[n-1] = chapterInfo{mapName: c.mapName}
+	} else {
+		m.chapters = append(m.chapters, chapterInfo{mapName: c.mapName})
+	}
+
+	a, b := m.scores()
+	log.Info().Int("match", len(o.game.matches)).Int("chapter", len(m.chapters)).Int("team_a_score", a).Int("team_b_score", b).
+		Msgf("Chapter %q of campaign %q loading", c.mapName, c.campaign)
+
+	o.game.emit(ChapterChanged{
+		EventTime:  srcds.EventTime{At: at},
+		Match:      len(o.game.matches),
+		Campaign:   c.campaign,
+		Chapter:    len(m.chapters),
+		Map:        c.mapName,
+		TeamAScore: a,
+		TeamBScore: b,
+	})
+}
+
+// startRound begins tracking the next half of the current chapter; a round that restarts before it ended is replaced
+func (o *Observer) startRound(at time.Time) {
+	m := o.game.currentMatch(at)
+	c := o.game.currentChapter(at)
+	half := len(c.rounds) + 1
+
+	survivors, ok := m.survivingTeam()
+	if !ok {
+		// Without any known survivors the teams are assumed to swap sides between the halves of a chapter
+		survivors = o.game.currentSurvivors()
+		if half%2 == 0 {
+			survivors = c.rounds[half-2].survivors.opponent()
+		}
+	}
+
+	if o.game.survivors != noTeam && survivors != o.game.survivors {
+		log.Info().Int("match", len(o.game.matches)).Int("chapter", len(m.chapters)).Msgf("Team %v is now playing as the survivors", survivors)
+
+		o.game.emit(TeamsSwapped{
+			EventTime: srcds.EventTime{At: at},
+			Match:     len(o.game.matches),
+			Chapter:   len(m.chapters),
+			Half:      half,
+			Survivors: string(survivors),
+			Infected:  string(survivors.opponent()),
+		})
+	}
+
+	o.game.survivors = survivors
+	c.current = roundInfo{started: at, survivors: survivors}
+	c.roundLive = true
+
+	log.Info().Int("match", len(o.game.matches)).Int("chapter", len(m.chapters)).Int("half", half).Msg("Round Start")
+}
+
+// endRound records the current half of the chapter as completed
+func (o *Observer) endRound(at time.Time) {
+	m := o.game.currentMatch(at)
+	c := o.game.currentChapter(at)
+
+	if !c.roundLive {
+		return
+	}
+
+	round := c.current
+	round.ended = at
+	c.rounds = append(c.rounds, round)
+	c.current = roundInfo{}
+	c.roundLive = false
+
+	a, b := m.scores()
+	log.Info().Int("match", len(o.game.matches)).Int("chapter", len(m.chapters)).Int("half", len(c.rounds)).
+		Int("team_a_score", a).Int("team_b_score", b).Msgf("Round over; team %v scored %d as the survivors", round.survivors, round.score)
+
+	o.game.emit(RoundEnded{
+		EventTime:  srcds.EventTime{At: at},
+		Match:      len(o.game.matches),
+		Chapter:    len(m.chapters),
+		Half:       len(c.rounds),
+		Survivors:  string(round.survivors),
+		Score:      round.score,
+		TeamAScore: a,
+		TeamBScore: b,
+	})
+}
+
+// recordScore records the survivors' score for the current round, or the round that just ended if none is being played
+func (o *Observer) recordScore(at time.Time, s teamScored) {
+	if s.affiliation != survivor {
+		// Only the survivors score in versus; the infected score by keeping the survivors from scoring
+		return
+	}
+
+	m := o.game.currentMatch(at)
+	c := o.game.currentChapter(at)
+
+	round, half := &c.current, len(c.rounds)+1
+	if !c.roundLive {
+		if len(c.rounds) == 0 {
+			log.Warn().Int("score", s.score).Msg("Survivor score reported before any round was observed; ignoring")
+			return
+		}
+
+		round, half = &c.rounds[len(c.rounds)-1], len(c.rounds)
+	}
+
+	round.score, round.scored = s.score, true
+	a, b := m.scores()
+
+	o.game.emit(DistanceScored{
+		EventTime:  srcds.EventTime{At: at},
+		Match:      len(o.game.matches),
+		Chapter:    len(m.chapters),
+		Half:       half,
+		Team:       string(round.survivors),
+		Score:      s.score,
+		TeamAScore: a,
+		TeamBScore: b,
+	})
+}
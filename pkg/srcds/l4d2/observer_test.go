@@ -0,0 +1,102 @@
+package l4d2
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+)
+
+func Test_Observer_versusCampaign(t *testing.T) {
+	file, err := os.Open("./testdata/c2_versus.log")
+	if err != nil {
+		t.Fatalf("Could not open log file: %v", err)
+	}
+	defer file.Close()
+
+	actual := []string{}
+
+	sut := NewObserver()
+	sut.OnEvent(func(e srcds.Event) {
+		switch v := e.(type) {
+		case ChapterChanged:
+			actual = append(actual, fmt.Sprintf("match %d chapter %d %s (%d-%d)", v.Match, v.Chapter, v.Map, v.TeamAScore, v.TeamBScore))
+		case DistanceScored:
+			actual = append(actual, fmt.Sprintf("team %s scored %d in half %d (%d-%d)", v.Team, v.Score, v.Half, v.TeamAScore, v.TeamBScore))
+		case RoundEnded:
+			actual = append(actual, fmt.Sprintf("chapter %d half %d over; team %s survived for %d", v.Chapter, v.Half, v.Survivors, v.Score))
+		case TeamsSwapped:
+			actual = append(actual, fmt.Sprintf("team %s survivors, team %s infected", v.Survivors, v.Infected))
+		}
+	})
+	sut.Read(file)
+	sut.Wait()
+
+	expected := []string{
+		"match 1 chapter 1 c2m1_highway (0-0)",
+		"team A scored 412 in half 1 (412-0)",
+		"chapter 1 half 1 over; team A survived for 412",
+		"team B survivors, team A infected",
+		"chapter 1 half 2 over; team B survived for 0",
+		"team B scored 188 in half 2 (412-188)",
+		"match 1 chapter 2 c2m2_fairground (412-188)",
+		"team B scored 350 in half 1 (412-538)",
+		"chapter 2 half 1 over; team B survived for 350",
+		"team A survivors, team B infected",
+		"team A scored 600 in half 2 (1012-538)",
+		"chapter 2 half 2 over; team A survived for 600",
+		"match 2 chapter 1 c3m1_plankcountry (0-0)",
+	}
+
+	if len(actual) != len(expected) {
+		t.Fatalf("Expected events %q not %q.", expected, actual)
+	}
+
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("Expected event %q not %q.", expected[i], actual[i])
+		}
+	}
+
+	matches := sut.Matches()
+	if len(matches) != 2 {
+		t.Fatalf("Expected 2 matches not %d.", len(matches))
+	}
+
+	m := matches[0]
+	if m.Campaign != "c2" || len(m.Chapters) != 2 || m.TeamAScore != 1012 || m.TeamBScore != 538 || m.Ended.IsZero() {
+		t.Errorf("Unexpected match snapshot %+v.", m)
+	}
+
+	if r := m.Chapters[0].Rounds[1]; r.Survivors != "B" || r.Score != 188 || !r.Scored {
+		t.Errorf("Unexpected round snapshot %+v.", r)
+	}
+
+	if p := m.Players["STEAM_1:0:1001"]; p.Team != "A" || p.Affiliation != "Survivor" {
+		t.Errorf("Unexpected stats for Alpha %+v.", p)
+	}
+
+	if p := m.Players["STEAM_1:0:2002"]; p.Team != "B" || p.Affiliation != "Infected" {
+		t.Errorf("Unexpected stats for Yankee %+v.", p)
+	}
+}
+
+func Test_Observer_swapAssumedWithoutSurvivors(t *testing.T) {
+	sut := NewObserver()
+
+	swaps := 0
+	sut.OnEvent(func(e srcds.Event) {
+		if _, ok := e.(TeamsSwapped); ok {
+			swaps++
+		}
+	})
+
+	for _, message := range []string{`Loading map "c1m1_hotel"`, `World triggered "Round_Start"`, `World triggered "Round_End"`, `World triggered "Round_Start"`} {
+		sut.processLogEntry(srcds.LogEntry{Message: message})
+	}
+
+	if m, _ := sut.CurrentMatch(); swaps != 1 || m.Survivors != "B" {
+		t.Errorf("Expected the teams to be assumed to swap for the second half, not %d swaps with %q surviving.", swaps, m.Survivors)
+	}
+}
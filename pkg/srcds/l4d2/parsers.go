@@ -0,0 +1,92 @@
+package l4d2
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+)
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+func parseAffiliation(af string) (affiliation affiliation, ok bool) {
+	switch a := strings.ToUpper(strings.TrimSpace(af)); a {
+	case "SURVIVOR":
+		return survivor, true
+	case "INFECTED":
+		return infected, true
+	case "SPECTATOR":
+		return spectator, true
+	case "", "UNASSIGNED":
+		return unassigned, true
+	}
+
+	return unassigned, false
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+var clientJoinedTeamRegex = regexp.MustCompile(`^joined team "(\w+)"$`)
+
+func parseClientJoinedTeam(clientLog srcds.ClientLogEntry) (affiliation, bool) {
+	tokens := clientJoinedTeamRegex.FindStringSubmatch(clientLog.Message)
+
+	if len(tokens) != 2 {
+		return unassigned, false
+	}
+
+	return parseAffiliation(tokens[1])
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// chapter identifies a campaign map; official maps are named c<campaign>m<chapter>_<name>
+type chapter struct {
+	mapName  string
+	campaign string // e.g. c2; the map name for maps that don't follow the official naming
+}
+
+var chapterRegex = regexp.MustCompile(`^(c\d+)m\d+_\w+$`)
+
+func parseChapter(mapName string) chapter {
+	r := chapter{mapName: mapName, campaign: mapName}
+
+	if c := chapterRegex.FindStringSubmatch(mapName); len(c) == 2 {
+		r.campaign = c[1]
+	}
+
+	return r
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+func parseRoundEnd(le srcds.LogEntry) (ok bool) {
+	return le.Message == `World triggered "Round_End"`
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+func parseRoundStart(le srcds.LogEntry) (ok bool) {
+	return le.Message == `World triggered "Round_Start"`
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+var teamScoredRegex = regexp.MustCompile(`^Team "(Survivor|Infected)" scored "(\d+)" with "(\d+)" players$`)
+
+// teamScored is sent as a versus round ends; the survivors' score is the distance they traveled plus their bonus
+type teamScored struct {
+	affiliation affiliation
+	score       int
+	playerCount int
+}
+
+func parseTeamScored(le srcds.LogEntry) (teamScored, bool) {
+	tokens := teamScoredRegex.FindStringSubmatch(le.Message)
+
+	if len(tokens) != 4 {
+		return teamScored{}, false
+	}
+
+	r := teamScored{}
+	r.playerCount, _ = strconv.Atoi(tokens[3])
+	r.score, _ = strconv.Atoi(tokens[2])
+	r.affiliation, _ = parseAffiliation(tokens[1])
+
+	return r, true
+}
@@ -0,0 +1,93 @@
+package l4d2
+
+import (
+	"testing"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+)
+
+func clientLog(t *testing.T, message string) srcds.ClientLogEntry {
+	t.Helper()
+
+	cle, ok := srcds.ParseClientLogEntry(srcds.LogEntry{Message: message})
+	if !ok {
+		t.Fatalf("Message %q should have been a client log entry.", message)
+	}
+
+	return cle
+}
+
+func Test_parseAffiliation(t *testing.T) {
+	validCases := map[string]affiliation{``: unassigned, `Survivor`: survivor, ` infected`: infected, `Spectator`: spectator, `Unassigned`: unassigned}
+
+	for input, expected := range validCases {
+		if actual, ok := parseAffiliation(input); !ok || actual != expected {
+			t.Errorf("Expected affiliation %q to parse as %q not %q.", input, expected, actual)
+		}
+	}
+
+	if _, ok := parseAffiliation("Red"); ok {
+		t.Error("Affiliation Red should NOT have successfully parsed.")
+	}
+}
+
+func Test_parseClientJoinedTeam(t *testing.T) {
+	if actual, ok := parseClientJoinedTeam(clientLog(t, `"Alpha<2><STEAM_1:0:1001><Survivor>" joined team "Infected"`)); !ok || actual != infected {
+		t.Errorf("Expected the team to be Infected not %q.", actual)
+	}
+
+	if _, ok := parseClientJoinedTeam(clientLog(t, `"Alpha<2><STEAM_1:0:1001><>" joined team "Blue"`)); ok {
+		t.Error("An unknown team should NOT have successfully parsed.")
+	}
+}
+
+func Test_parseChapter(t *testing.T) {
+	validCases := []struct {
+		mapName  string
+		expected chapter
+	}{
+		{"c2m1_highway", chapter{mapName: "c2m1_highway", campaign: "c2"}},
+		{"c13m4_cutthroatcreek", chapter{mapName: "c13m4_cutthroatcreek", campaign: "c13"}},
+		{"l4d2_darkblood01_tanker", chapter{mapName: "l4d2_darkblood01_tanker", campaign: "l4d2_darkblood01_tanker"}},
+	}
+
+	for _, test := range validCases {
+		if actual := parseChapter(test.mapName); actual != test.expected {
+			t.Errorf("Expected chapter %+v not %+v.", test.expected, actual)
+		}
+	}
+}
+
+func Test_parseRounds(t *testing.T) {
+	if !parseRoundStart(srcds.LogEntry{Message: `World triggered "Round_Start"`}) {
+		t.Error("A round start should have successfully parsed.")
+	}
+
+	if !parseRoundEnd(srcds.LogEntry{Message: `World triggered "Round_End"`}) {
+		t.Error("A round end should have successfully parsed.")
+	}
+
+	if parseRoundStart(srcds.LogEntry{Message: `World triggered "Round_End"`}) {
+		t.Error("A round end should NOT have successfully parsed as a round start.")
+	}
+}
+
+func Test_parseTeamScored(t *testing.T) {
+	validCases := []struct {
+		message  string
+		expected teamScored
+	}{
+		{`Team "Survivor" scored "412" with "4" players`, teamScored{affiliation: survivor, score: 412, playerCount: 4}},
+		{`Team "Infected" scored "0" with "4" players`, teamScored{affiliation: infected, score: 0, playerCount: 4}},
+	}
+
+	for _, test := range validCases {
+		if actual, ok := parseTeamScored(srcds.LogEntry{Message: test.message}); !ok || actual != test.expected {
+			t.Errorf("Expected team score %+v not %+v.", test.expected, actual)
+		}
+	}
+
+	if _, ok := parseTeamScored(srcds.LogEntry{Message: `Team "Red" scored "2" with "6" players`}); ok {
+		t.Error("An unknown team should NOT have successfully parsed.")
+	}
+}
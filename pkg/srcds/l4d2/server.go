@@ -0,0 +1,79 @@
+package l4d2
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+)
+
+// Server represents an interactive L4D2 SRCDS instance
+type Server struct {
+	*srcds.Server
+	Observer
+	wg sync.WaitGroup
+}
+
+// NewServer for interacting with an L4D2 SRCDS instance
+func NewServer() *Server {
+	s := &Server{
+		Server: srcds.NewServer(),
+	}
+
+	s.Observer.srcdsObserver = s.Server.Observer
+
+	return s
+}
+
+// SetExec prepares the L4D2 SRCDS instance for execution using the given arguments
+func (s *Server) SetExec(arg string, args ...string) error {
+	err := s.Server.SetExec(arg, args...)
+	if err != nil {
+		return fmt.Errorf("Unable to SetExec for L4D2 Server: %w", err)
+	}
+
+	return nil
+}
+
+// Read starts the L4D2 server and processes its output
+func (s *Server) Read() error {
+	c, err := s.Listen()
+	if err != nil {
+		return err
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		for range c {
+		}
+	}()
+
+	return nil
+}
+
+// Listen starts the L4D2 server, processes its output, and returns its log stream
+func (s *Server) Listen() (<-chan srcds.LogEntry, error) {
+	c, err := s.Server.Listen()
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't listen to SRCDS server: %w", err)
+	}
+
+	s.wg.Add(1)
+	logStream := make(chan srcds.LogEntry, 6)
+	go func(in <-chan srcds.LogEntry, out chan<- srcds.LogEntry) {
+		defer s.wg.Done()
+		defer close(out)
+		for le := range in {
+			s.processLogEntry(le)
+			out <- le
+		}
+	}(c, logStream)
+
+	return logStream, nil
+}
+
+// Wait blocks until the L4D2 server stops executing
+func (s *Server) Wait() {
+	s.wg.Wait()
+}
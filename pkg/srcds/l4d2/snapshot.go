@@ -0,0 +1,113 @@
+package l4d2
+
+import (
+	"time"
+)
+
+// MatchSnapshot is a point-in-time copy of a versus campaign's state
+type MatchSnapshot struct {
+	Number     int
+	Campaign   string
+	Started    time.Time
+	Ended      time.Time
+	TeamAScore int
+	TeamBScore int
+	Survivors  string // the team currently (or most recently) playing as the survivors; A or B
+	Chapters   []ChapterSnapshot
+	Players    map[string]PlayerStats // keyed by SteamID
+}
+
+// ChapterSnapshot is a point-in-time copy of a chapter's state
+type ChapterSnapshot struct {
+	Number  int
+	MapName string
+	Rounds  []RoundSnapshot // completed rounds
+}
+
+// RoundSnapshot is a point-in-time copy of a completed half of a chapter
+type RoundSnapshot struct {
+	Half      int
+	Started   time.Time
+	Ended     time.Time
+	Survivors string // A or B
+	Score     int
+	Scored    bool // false if the server never reported the survivors' score
+}
+
+// PlayerStats are a player's details for a match
+type PlayerStats struct {
+	Username    string
+	Team        string // A or B; empty if the player has yet to play either side
+	Affiliation string // Survivor, Infected, Spectator, or Unassigned
+}
+
+// CurrentMatch returns a snapshot of the current match; false if no match has been observed
+func (o *Observer) CurrentMatch() (MatchSnapshot, bool) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	if len(o.game.matches) == 0 {
+		return MatchSnapshot{}, false
+	}
+
+	i := len(o.game.matches) - 1
+	return o.game.matches[i].snapshot(i+1, o.game.survivors), true
+}
+
+// Matches returns snapshots of every observed match
+func (o *Observer) Matches() []MatchSnapshot {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	r := make([]MatchSnapshot, 0, len(o.game.matches))
+	for i := range o.game.matches {
+		r = append(r, o.game.matches[i].snapshot(i+1, o.game.survivors))
+	}
+
+	return r
+}
+
+func (m *matchInfo) snapshot(number int, survivors versusTeam) MatchSnapshot {
+	r := MatchSnapshot{
+		Number:    number,
+		Campaign:  m.campaign,
+		Started:   m.started,
+		Ended:     m.ended,
+		Survivors: string(survivors),
+		Chapters:  make([]ChapterSnapshot, 0, len(m.chapters)),
+		Players:   make(map[string]PlayerStats, len(m.players)),
+	}
+
+	r.TeamAScore, r.TeamBScore = m.scores()
+
+	for i, c := range m.chapters {
+		s := ChapterSnapshot{
+			Number:  i + 1,
+			MapName: c.mapName,
+			Rounds:  make([]RoundSnapshot, 0, len(c.rounds)),
+		}
+
+		for j, round := range c.rounds {
+			s.Rounds = append(s.Rounds, RoundSnapshot{
+				Half:      j + 1,
+				Started:   round.started,
+				Ended:     round.ended,
+				Survivors: string(round.survivors),
+				Score:     round.score,
+				Scored:    round.scored,
+			})
+		}
+
+		r.Chapters = append(r.Chapters, s)
+	}
+
+	for id, p := range m.players {
+		r.Players[id] = PlayerStats{
+			Username:    p.username,
+			Team:        string(p.team),
+			Affiliation: string(p.affiliation),
+		}
+	}
+
+	return r
+}
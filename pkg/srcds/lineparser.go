@@ -0,0 +1,117 @@
+package srcds
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ParseAction instructs the observer what to do with a log entry after a registered parser handled it
+type ParseAction uint8
+
+const (
+	// ParseContinue offers the log entry to lower priority parsers before sending it to the log stream
+	ParseContinue ParseAction = iota
+	// ParseStop skips lower priority parsers; the log entry is still sent to the log stream
+	ParseStop
+	// ParseConsume skips lower priority parsers and keeps the log entry out of the log stream
+	ParseConsume
+)
+
+// LineParser matches log entries and handles the ones it matched. A log entry matches if its message starts with
+// Prefix, matches Regex, and satisfies Match; unset criteria are ignored but at least one must be set.
+type LineParser struct {
+	Name     string // used when logging; optional
+	Prefix   string
+	Regex    *regexp.Regexp
+	Match    func(LogEntry) bool
+	Priority int // higher priority parsers are offered log entries first; equal priorities keep their registration order
+	// Handler is called with a matched log entry and the Regex's submatches (nil without a Regex)
+	Handler func(le LogEntry, submatches []string) ParseAction
+}
+
+// match determines if the parser matches the log entry, returning the Regex's submatches
+func (p LineParser) match(le LogEntry) (submatches []string, ok bool) {
+	if len(p.Prefix) > 0 && !strings.HasPrefix(le.Message, p.Prefix) {
+		return nil, false
+	}
+
+	if p.Regex != nil {
+		if submatches = p.Regex.FindStringSubmatch(le.Message); submatches == nil {
+			return nil, false
+		}
+	}
+
+	if p.Match != nil && !p.Match(le) {
+		return nil, false
+	}
+
+	return submatches, true
+}
+
+// RegisterParser adds a parser that is offered every log entry before it is sent to the log stream; parsers registered
+// while the stream is being observed are offered the log entries that follow
+func (o *Observer) RegisterParser(p LineParser) error {
+	if p.Handler == nil {
+		return errors.New("parser handler cannot be nil")
+	}
+
+	if len(p.Prefix) == 0 && p.Regex == nil && p.Match == nil {
+		return errors.New("parser must have a prefix, regex, or match function")
+	}
+
+	o.parsersMux.Lock()
+	defer o.parsersMux.Unlock()
+
+	// The observer may be offering a log entry to the current parsers; replace them rather than sorting them in place
+	parsers := append(append(make([]LineParser, 0, len(o.parsers)+1), o.parsers...), p)
+	sort.SliceStable(parsers, func(i, j int) bool {
+		return parsers[i].Priority > parsers[j].Priority
+	})
+	o.parsers = parsers
+
+	return nil
+}
+
+// SetUnmatchedHandler replaces the handling of log entries that none of the registered parsers matched; by default they
+// are sent to the log stream. A nil handler restores the default.
+func (o *Observer) SetUnmatchedHandler(h func(LogEntry) (forward bool)) {
+	o.parsersMux.Lock()
+	o.unmatchedHandler = h
+	o.parsersMux.Unlock()
+}
+
+// applyParsers offers the log entry to the registered parsers, determining if it should be sent to the log stream
+func (o *Observer) applyParsers(le LogEntry) (forward bool) {
+	o.parsersMux.RLock()
+	parsers, unmatchedHandler := o.parsers, o.unmatchedHandler
+	o.parsersMux.RUnlock()
+
+	matched := false
+
+	for _, p := range parsers {
+		submatches, ok := p.match(le)
+		if !ok {
+			continue
+		}
+
+		matched = true
+
+		switch p.Handler(le, submatches) {
+		case ParseStop:
+			return true
+		case ParseConsume:
+			log.Debug().Str("parser", p.Name).Msgf("Log entry consumed: %q", le.Message)
+			return false
+		}
+	}
+
+	if !matched && unmatchedHandler != nil {
+		return unmatchedHandler(le)
+	}
+
+	return true
+}
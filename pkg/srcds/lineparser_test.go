@@ -0,0 +1,125 @@
+package srcds
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func Test_RegisterParser(t *testing.T) {
+	sut := NewObserver()
+	calls := []string{}
+
+	record := func(name string, action ParseAction) func(LogEntry, []string) ParseAction {
+		return func(le LogEntry, submatches []string) ParseAction {
+			calls = append(calls, name+":"+strings.Join(submatches, ","))
+			return action
+		}
+	}
+
+	parsers := []LineParser{
+		{Name: "low", Prefix: "Team ", Priority: -1, Handler: record("low", ParseContinue)},
+		{Name: "regex", Regex: regexp.MustCompile(`^Team "(\w+)" scored "(\d+)"`), Handler: record("regex", ParseContinue)},
+		{Name: "high", Prefix: "Team ", Priority: 10, Handler: record("high", ParseContinue)},
+		{Name: "stop", Match: func(le LogEntry) bool { return strings.Contains(le.Message, "stop") }, Priority: 5, Handler: record("stop", ParseStop)},
+		{Name: "consume", Prefix: "secret", Handler: record("consume", ParseConsume)},
+	}
+
+	for _, p := range parsers {
+		if err := sut.RegisterParser(p); err != nil {
+			t.Fatalf("Parser %q should have registered: %v", p.Name, err)
+		}
+	}
+
+	unmatched := []string{}
+	sut.SetUnmatchedHandler(func(le LogEntry) bool {
+		unmatched = append(unmatched, le.Message)
+		return !strings.HasPrefix(le.Message, "drop")
+	})
+
+	input := strings.Join([]string{
+		`L 10/14/2023 - 18:00:00: Team "CT" scored "3" with "5" players`,
+		`L 10/14/2023 - 18:00:01: Team "TERRORIST" stop`,
+		`L 10/14/2023 - 18:00:02: secret message`,
+		`L 10/14/2023 - 18:00:03: drop this`,
+		`L 10/14/2023 - 18:00:04: keep this`,
+	}, "\n")
+
+	forwarded := []string{}
+	for le := range sut.Listen(strings.NewReader(input)) {
+		forwarded = append(forwarded, le.Message)
+	}
+
+	expectedCalls := []string{"high:", "regex:Team \"CT\" scored \"3\",CT,3", "low:", "high:", "stop:", "consume:"}
+	if strings.Join(calls, "|") != strings.Join(expectedCalls, "|") {
+		t.Errorf("Expected parser calls %q not %q.", expectedCalls, calls)
+	}
+
+	expectedForwarded := []string{`Team "CT" scored "3" with "5" players`, `Team "TERRORIST" stop`, `keep this`}
+	if strings.Join(forwarded, "|") != strings.Join(expectedForwarded, "|") {
+		t.Errorf("Expected log entries %q to be forwarded not %q.", expectedForwarded, forwarded)
+	}
+
+	if len(unmatched) != 2 {
+		t.Errorf("Expected 2 unmatched log entries not %q.", unmatched)
+	}
+}
+
+func Test_RegisterParser_invalid(t *testing.T) {
+	sut := NewObserver()
+
+	invalidCases := []LineParser{
+		{Prefix: "Team "},
+		{Handler: func(LogEntry, []string) ParseAction { return ParseContinue }},
+	}
+
+	for _, p := range invalidCases {
+		if err := sut.RegisterParser(p); err == nil {
+			t.Errorf("Parser %+v should NOT have registered.", p)
+		}
+	}
+}
+
+func Test_RegisterParser_whileObserving(t *testing.T) {
+	sut := NewObserver()
+	r, w := io.Pipe()
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		for i := 0; i < 50; i++ {
+			fmt.Fprintf(w, "L 10/14/2023 - 18:00:00: Team \"CT\" scored \"%d\" with \"5\" players\n", i)
+		}
+		w.Close()
+	}()
+
+	stream := sut.Listen(r)
+
+	counted := 0
+	for i := 0; i < 10; i++ {
+		if err := sut.RegisterParser(LineParser{Name: fmt.Sprintf("count %d", i), Prefix: "Team ", Handler: func(LogEntry, []string) ParseAction {
+			counted++
+			return ParseContinue
+		}}); err != nil {
+			t.Fatalf("Parser %d should have registered while observing: %v", i, err)
+		}
+
+		sut.SetUnmatchedHandler(func(LogEntry) bool { return true })
+	}
+
+	forwarded := 0
+	for range stream {
+		forwarded++
+	}
+	<-done
+
+	if forwarded != 50 {
+		t.Errorf("Expected all 50 log entries to be forwarded not %d.", forwarded)
+	}
+
+	if counted > 500 {
+		t.Errorf("Expected each parser to be offered each log entry at most once; got %d calls.", counted)
+	}
+}
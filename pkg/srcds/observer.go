@@ -16,9 +16,18 @@ const (
 	eolWindows = "\r\n"
 )
 
-// AddCvarWatcher instructs the system to keep track of the specified cvar name
+// AddCvarWatcher instructs the system to keep track of the specified cvar name; names are checked against cvarlist once
+// it has been received
 func (o *Observer) AddCvarWatcher(names ...string) {
 	o.cvars.addWatcher(names...)
+
+	if o.cvarList.isListed() {
+		for _, name := range names {
+			if info, found := o.cvarList.lookup(name); !found || info.IsCommand {
+				log.Warn().Str("cvar", name).Msg("Watched cvar is unknown to the server")
+			}
+		}
+	}
 }
 
 // AddCvarWatcherDefault instructs the system to keep track of the specified cvar,  providing a default value
@@ -26,9 +35,25 @@ func (o *Observer) AddCvarWatcherDefault(name string, defaultValue string) {
 	o.cvars.seedWatcher(name, defaultValue)
 }
 
+// ConsoleLine is a non-log line of console output
+type ConsoleLine struct {
+	Text string
+	// LogEntriesBefore is the number of log entries sent to the log stream before the line was output; allowing
+	// consumers of the log stream to process the line in order
+	LogEntriesBefore uint64
+}
+
+// OnConsoleLine registers a handler to be called with every non-log line of console output; handlers must be registered
+// before the stream is observed and are called in the order they were registered
+func (o *Observer) OnConsoleLine(h func(ConsoleLine)) {
+	if h != nil {
+		o.consoleHandlers = append(o.consoleHandlers, h)
+	}
+}
+
 // NewObserver for SRCDS log streams
 func NewObserver() *Observer {
-	r := &Observer{}
+	r := &Observer{broadcaster: NewBroadcaster()}
 
 	if strings.ToLower(runtime.GOOS) == "windows" {
 		r.EndOfLine = eolWindows
@@ -49,13 +74,19 @@ func (o *Observer) Read(r io.Reader) {
 	}()
 }
 
-// Listen to a SRCDS log output stream
+// Listen to a SRCDS log output stream; the stream must be drained as the observer blocks while it is full, consumers
+// that may fall behind should Subscribe instead
 func (o *Observer) Listen(r io.Reader) <-chan LogEntry {
 	br := bufio.NewReader(r)
 
 	firstLine, err := br.ReadString(0x0A)
-	if err == io.EOF {
-		return nil
+	if err == io.EOF && len(firstLine) == 0 {
+		// Nothing was output; the stream is closed so consumers aren't left waiting on it
+		o.closeConsoleStreams()
+		o.broadcaster.Close()
+		logStream := make(chan LogEntry)
+		close(logStream)
+		return logStream
 	}
 
 	// Determine EOL delimiter as it may not match operating system's EOL
@@ -81,6 +112,8 @@ func (o *Observer) Listen(r io.Reader) <-chan LogEntry {
 
 		defer close(c)
 		defer o.wg.Done()
+		defer o.closeConsoleStreams()
+		defer o.broadcaster.Close()
 
 		o.processMessage(firstLine, c)
 
@@ -93,11 +126,45 @@ func (o *Observer) Listen(r io.Reader) <-chan LogEntry {
 	return logStream
 }
 
+// Subscribe to the log stream; each subscriber has its own buffer and overflow policy so one that falls behind only
+// stalls the observer if it blocks when full. Subscriptions are closed once the log stream ends.
+func (o *Observer) Subscribe(name string, size int, policy OverflowPolicy) *Subscription {
+	return o.broadcaster.Subscribe(name, size, policy)
+}
+
+// SubscriptionStats returns the delivery metrics of every subscriber to the log stream
+func (o *Observer) SubscriptionStats() []SubscriptionStats {
+	return o.broadcaster.Stats()
+}
+
 // Wait for the SRCDS observer to exit naturally.
 func (o *Observer) Wait() {
 	o.wg.Wait()
 }
 
+// CvarHistory returns the most recent changes of a watched cvar, oldest first
+func (o *Observer) CvarHistory(name string) []CvarChange {
+	return o.cvars.changes(name)
+}
+
+// OnCvarChange registers a handler to be called whenever a watched cvar takes on a new value; handlers are called from
+// the observer's goroutine and should be registered before the stream is observed
+func (o *Observer) OnCvarChange(h func(CvarChange)) {
+	if h != nil {
+		o.cvars.onChange(h)
+	}
+}
+
+// TryCvarAsBool attempts to return a cvar as a boolean, returning a bool indicating if the provided fallback value was returned
+func (o *Observer) TryCvarAsBool(name string, fallback bool) (value bool, nonFallback bool) {
+	return o.cvars.tryBool(name, fallback)
+}
+
+// TryCvarAsFloat attempts to return a cvar as a float, returning a bool indicating if the provided fallback value was returned
+func (o *Observer) TryCvarAsFloat(name string, fallback float32) (value float32, nonFallback bool) {
+	return o.cvars.tryFloat(name, fallback)
+}
+
 // TryCvarAsInt attempts to return a cvar as an integer, returning a bool indicating if the provided fallback value was returned
 func (o *Observer) TryCvarAsInt(name string, fallback int) (value int, nonFallback bool) {
 	return o.cvars.tryInt(name, fallback)
@@ -109,11 +176,21 @@ func (o *Observer) TryCvarAsString(name, fallback string) (value string, nonFall
 }
 
 type Observer struct {
-	cvars      Cvars
-	EndOfLine  string
-	started    time.Time
-	statistics observerStatistics
-	wg         sync.WaitGroup
+	broadcaster           *Broadcaster
+	consoleHandlers       []func(ConsoleLine)
+	consoleOutputHandlers []consoleOutputHandler
+	consoleStreams        []chan ConsoleOutput
+	cvarList              CvarRegistry
+	cvars                 Cvars
+	EndOfLine             string
+	jsonBlock             *jsonBlock
+	logEntriesSent        uint64
+	parsers               []LineParser
+	parsersMux            sync.RWMutex // guards parsers and unmatchedHandler
+	started               time.Time
+	statistics            observerStatistics
+	unmatchedHandler      func(LogEntry) bool
+	wg                    sync.WaitGroup
 }
 
 type observerStatistics struct {
@@ -139,15 +216,41 @@ func (o *Observer) processMessage(line string, outEntries chan<- LogEntry) {
 			return
 		}
 
+		if le, ok = o.assembleJSONBlock(le); !ok {
+			return
+		}
+
+		if !o.applyParsers(le) {
+			return
+		}
+
+		o.broadcaster.Broadcast(le)
+
 		if outEntries != nil {
 			outEntries <- le
+			o.logEntriesSent++
 		}
 
 		return
 	}
 
+	if info, ok := parseCvarListEntry(line); ok {
+		o.cvarList.add(info)
+		if !info.IsCommand {
+			o.cvars.setIfWatchedFrom(info.Name, info.Value, time.Now(), CvarSourceConsole)
+		}
+		return
+	}
+
+	if total, ok := parseCvarListTotal(line); ok {
+		o.completeCvarList(total)
+		return
+	}
+
 	if cvarSet, ok := parseCvarResponse(line); ok {
-		o.cvars.setIfWatched(cvarSet.Name, cvarSet.Value, time.Now())
+		o.cvars.setIfWatchedFrom(cvarSet.Name, cvarSet.Value, time.Now(), CvarSourceConsole)
 		return
 	}
+
+	o.processConsoleLine(ConsoleLine{Text: line, LogEntriesBefore: o.logEntriesSent})
 }
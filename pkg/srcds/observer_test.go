@@ -3,6 +3,7 @@ package srcds
 import (
 	"bufio"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -90,3 +91,55 @@ func Test_NewObserver(t *testing.T) {
 		})
 	}
 }
+
+func Test_Observer_OnConsoleLine(t *testing.T) {
+	stream := strings.Join([]string{
+		`L 01/01/2000 - 10:11:12: Start the ship, Leela!`,
+		`Host_Error: something went wrong`,
+		``,
+		`"sv_cheats" = "0" ( def. "0" ) notify replicated`,
+		`Listing round backup files`,
+	}, "\n") + "\n"
+
+	actual := []ConsoleLine{}
+
+	sut := NewObserver()
+	sut.OnConsoleLine(func(line ConsoleLine) {
+		actual = append(actual, line)
+	})
+	sut.Read(strings.NewReader(stream))
+	sut.Wait()
+
+	expected := []ConsoleLine{
+		{Text: `Host_Error: something went wrong`, LogEntriesBefore: 1},
+		{Text: `Listing round backup files`, LogEntriesBefore: 1},
+	}
+
+	if len(actual) != len(expected) {
+		t.Fatalf("Expected console lines %+v not %+v.", expected, actual)
+	}
+
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("Expected console line %+v not %+v.", expected[i], actual[i])
+		}
+	}
+}
+
+func Test_Observer_Listen_emptyStream(t *testing.T) {
+	sut := NewObserver()
+	sub := sut.Subscribe("empty", 1, DropNewest)
+
+	c := sut.Listen(strings.NewReader(""))
+	if c == nil {
+		t.Fatal("Expected a closed log stream not a nil one.")
+	}
+
+	if _, open := <-c; open {
+		t.Error("Expected the log stream of an empty stream to be closed.")
+	}
+
+	if _, open := <-sub.C(); open {
+		t.Error("Expected subscriptions to an empty stream to be closed.")
+	}
+}
@@ -101,6 +101,43 @@ func ParseClientDisconnected(clientMsg ClientLogEntry) (ClientDisconnectedReason
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+var (
+	consoleCrashRegex          = regexp.MustCompile(`(?i)segmentation fault|core dumped|^Aborted\b`)
+	consoleErrorRegex          = regexp.MustCompile(`(?i)^error\b`)
+	consoleFatalErrorRegex     = regexp.MustCompile(`(?i)^fatal error\b`)
+	consoleHostErrorRegex      = regexp.MustCompile(`^Host_Error\b`)
+	consoleLoadFailureRegex    = regexp.MustCompile(`(?i)\b(failed to|couldn't|could not|unable to) load\b`)
+	consoleUnknownCommandRegex = regexp.MustCompile(`^Unknown command\b`)
+	consoleWarningRegex        = regexp.MustCompile(`(?i)\bwarning\b`)
+	consoleWorkshopRegex       = regexp.MustCompile(`(?i)\bworkshop\b`)
+)
+
+// parseConsoleSeverity classifies a line of console output; the first pattern to match determines the classification
+func parseConsoleSeverity(s string) (severity ConsoleSeverity, category string, ok bool) {
+	switch {
+	case consoleHostErrorRegex.MatchString(s):
+		return ConsoleFatal, "host_error", true
+	case consoleCrashRegex.MatchString(s):
+		return ConsoleFatal, "crash", true
+	case consoleFatalErrorRegex.MatchString(s):
+		return ConsoleFatal, "fatal_error", true
+	case consoleLoadFailureRegex.MatchString(s):
+		return ConsoleError, "load_failure", true
+	case consoleUnknownCommandRegex.MatchString(s):
+		return ConsoleWarning, "unknown_command", true
+	case consoleErrorRegex.MatchString(s):
+		return ConsoleError, "error", true
+	case consoleWarningRegex.MatchString(s):
+		return ConsoleWarning, "warning", true
+	case consoleWorkshopRegex.MatchString(s):
+		return ConsoleInfo, "workshop", true
+	}
+
+	return ConsoleInfo, "", false
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // CvarValueSet is sent when srcds outputs a cvar
 type CvarValueSet struct {
 	Name  string
@@ -109,7 +146,7 @@ type CvarValueSet struct {
 
 var (
 	cvarDescriptionRegex  = regexp.MustCompile(`^([\w]{3,}) -(?:| (-?[\w.;]{0,}))$`)
-	cvarQuotedValueRegex  = regexp.MustCompile(`^"([^\s]{3,})" = "([^\s"]{0,})"`)
+	cvarQuotedValueRegex  = regexp.MustCompile(`^"([^\s]{3,})" = "([^"]*)"`)
 	cvarServerFormatRegex = regexp.MustCompile(`^server_cvar: "([\w]{3,})" "(.*)"$`)
 )
 
@@ -149,6 +186,110 @@ func parseCvarResponse(s string) (cvar CvarValueSet, ok bool) {
 
 ///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+var (
+	cvarListEntryRegex = regexp.MustCompile(`^([\w+\-.]+)\s+: (.*?)\s*: (.*?)\s*: ?(.*)$`)
+	cvarListFlagRegex  = regexp.MustCompile(`"([\w]+)"`)
+	cvarListTotalRegex = regexp.MustCompile(`^\s*(\d+) total convars/concommands$`)
+)
+
+// parseCvarListEntry parses a line of cvarlist output (name : value : flags : description)
+func parseCvarListEntry(s string) (info CvarInfo, ok bool) {
+	tokens := cvarListEntryRegex.FindStringSubmatch(s)
+	if len(tokens) != 5 {
+		return CvarInfo{}, false
+	}
+
+	info = CvarInfo{
+		Name:        tokens[1],
+		Value:       strings.TrimSpace(tokens[2]),
+		Description: strings.TrimSpace(tokens[4]),
+	}
+
+	if info.Value == "cmd" {
+		info.IsCommand, info.Value = true, ""
+	}
+
+	for _, flag := range cvarListFlagRegex.FindAllStringSubmatch(tokens[3], -1) {
+		info.Flags = append(info.Flags, flag[1])
+	}
+
+	return info, true
+}
+
+// parseCvarListTotal parses the summary that ends cvarlist output
+func parseCvarListTotal(s string) (total int, ok bool) {
+	tokens := cvarListTotalRegex.FindStringSubmatch(s)
+	if len(tokens) != 2 {
+		return 0, false
+	}
+
+	total, err := strconv.Atoi(tokens[1])
+	return total, err == nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var jsonTrailingCommaRegex = regexp.MustCompile(`,(\s*[}\]])`)
+
+// stripJSONTrailingCommas removes the trailing commas SRCDS leaves in its JSON blocks
+func stripJSONTrailingCommas(s string) string {
+	return jsonTrailingCommaRegex.ReplaceAllString(s, "$1")
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var loadingMapRegex = regexp.MustCompile(`^Loading map "([^"]+)"$`)
+
+// ParseLoadingMap attempts to parse the map SRCDS is loading; workshop maps are named like workshop/123456789/de_x
+func ParseLoadingMap(le LogEntry) (mapName string, ok bool) {
+	tokens := loadingMapRegex.FindStringSubmatch(le.Message)
+	if len(tokens) != 2 {
+		return "", false
+	}
+
+	return tokens[1], true
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var startedMapRegex = regexp.MustCompile(`^Started map "([^"]+)"(?: \(CRC "[-\w]+"\))?$`)
+
+// ParseStartedMap attempts to parse the map SRCDS started; TF2 logs its CRC in hex
+func ParseStartedMap(le LogEntry) (mapName string, ok bool) {
+	tokens := startedMapRegex.FindStringSubmatch(le.Message)
+	if len(tokens) != 2 {
+		return "", false
+	}
+
+	return tokens[1], true
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// mapStatusRegex matches a map loading or starting; the first submatch is Loading or Started
+var mapStatusRegex = regexp.MustCompile(`^(Loading|Started) map "([^"]+)"`)
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var rconCommandRegex = regexp.MustCompile(`^rcon from "([^"]+)": command "(.*)"$`)
+
+// rconCommand is sent when a command is issued over rcon
+type rconCommand struct {
+	address string
+	command string
+}
+
+func parseRconCommand(le LogEntry) (rconCommand, bool) {
+	tokens := rconCommandRegex.FindStringSubmatch(le.Message)
+	if len(tokens) != 3 {
+		return rconCommand{}, false
+	}
+
+	return rconCommand{address: tokens[1], command: tokens[2]}, true
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 var serverQuitRegex = regexp.MustCompile(`^server_message: "quit"$`)
 
 func parseServerQuit(le LogEntry) (ok bool) {
@@ -1,6 +1,7 @@
 package srcds
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -33,6 +34,7 @@ func Test_ParseClient(t *testing.T) {
 		}
 
 		for name, tests := range validCases {
+			tests := tests
 			t.Run(name, func(t *testing.T) {
 				t.Parallel()
 				for _, test := range tests {
@@ -86,6 +88,7 @@ func Test_ParseClient(t *testing.T) {
 		}
 
 		for name, tests := range invalidCases {
+			tests := tests
 			t.Run(name, func(t *testing.T) {
 				t.Parallel()
 				for _, msg := range tests {
@@ -319,6 +322,7 @@ func Test_ParseClientDisconnected(t *testing.T) {
 		}
 
 		for name, tests := range invalidCases {
+			tests := tests
 			t.Run(name, func(t *testing.T) {
 				t.Parallel()
 				for _, test := range tests {
@@ -398,6 +402,7 @@ func Test_parseLogEntry(t *testing.T) {
 		}
 
 		for name, tests := range validCases {
+			tests := tests
 			t.Run(name, func(t *testing.T) {
 				t.Parallel()
 				for _, test := range tests {
@@ -472,6 +477,7 @@ func Test_parseCvar(t *testing.T) {
 		}
 
 		for _, test := range validCases {
+			test := test
 			t.Run(test.msg, func(t *testing.T) {
 				t.Parallel()
 				if actual, ok := parseCvar(LogEntry{Message: test.msg}); !ok {
@@ -508,6 +514,7 @@ func Test_parseCvar(t *testing.T) {
 		}
 
 		for name, tests := range invalidCases {
+			tests := tests
 			t.Run(name, func(t *testing.T) {
 				t.Parallel()
 				for _, test := range tests {
@@ -548,6 +555,7 @@ func Test_parseCvarResponse(t *testing.T) {
 			{`"sv_tags" = ""`, "sv_tags", ""},
 			{`"mp_respawnwavetime" = "10.0"`, "mp_respawnwavetime", "10.0"},
 			{`"metamod_version" = "1.11.0-dev+1097V"`, "metamod_version", "1.11.0-dev+1097V"},
+			{`"hostname" = "Laclede's LAN // Tournament"`, "hostname", "Laclede's LAN // Tournament"},
 			{`"mp_do_warmup_period" = "1" min. 0.000000 max. 1.000000 game replicated          - Whether or not to do a warmup period at the start of a match.`, "mp_do_warmup_period", "1"},
 			{`"mp_maxrounds" = "7" ( def. "0" ) min. 0.000000 game notify replicated           - max number of rounds to play before server changes maps`, "mp_maxrounds", "7"},
 			{`"mp_maxrounds" = "30" ( def. "0" ) min. 0.000000 game notify replicated          - max number of rounds to play before server changes maps`, "mp_maxrounds", "30"},
@@ -555,6 +563,7 @@ func Test_parseCvarResponse(t *testing.T) {
 		}
 
 		for _, test := range validCases {
+			test := test
 			t.Run(test.msg, func(t *testing.T) {
 				t.Parallel()
 				if actual, ok := parseCvarResponse(test.msg); !ok {
@@ -650,3 +659,130 @@ func Test_parseServerQuit(t *testing.T) {
 		}
 	})
 }
+
+func Test_parseRconCommand(t *testing.T) {
+	actual, ok := parseRconCommand(LogEntry{Message: `rcon from "192.168.1.5:51234": command "mp_maxrounds 16; mp_restartgame 1"`})
+	if !ok || actual.address != "192.168.1.5:51234" || actual.command != "mp_maxrounds 16; mp_restartgame 1" {
+		t.Errorf("Unexpected rcon command %+v.", actual)
+	}
+
+	if _, ok := parseRconCommand(LogEntry{Message: `rcon from "192.168.1.5:51234": Bad Password`}); ok {
+		t.Error("A bad rcon password should NOT have successfully parsed.")
+	}
+}
+
+func Test_ParseLoadingMap(t *testing.T) {
+	validCases := []struct {
+		msg         string
+		expectedMap string
+	}{
+		{`Loading map "de_nuke"`, "de_nuke"},
+		{`Loading map "c2m1_highway"`, "c2m1_highway"},
+		{`Loading map "workshop/125438255/de_cbble"`, "workshop/125438255/de_cbble"},
+	}
+
+	t.Run("Valid Cases", func(t *testing.T) {
+		for _, test := range validCases {
+			if actualMap, ok := ParseLoadingMap(LogEntry{Message: test.msg}); !ok {
+				t.Errorf("Message %q should have successfully parsed.", test.msg)
+			} else if actualMap != test.expectedMap {
+				t.Errorf("Expected map %q but got map %q from message %q.", test.expectedMap, actualMap, test.msg)
+			}
+		}
+	})
+
+	invalidCases := []string{
+		``,
+		`"mp_tournament" = "0"`,
+		`Started map "de_nuke" (CRC "-1787457339")`,
+		`rcon from "192.168.1.107:61968": command "echo HLSW: Test"`,
+		`World triggered "Round_Start"`,
+	}
+
+	t.Run("Invalid Cases", func(t *testing.T) {
+		for _, msg := range invalidCases {
+			if _, ok := ParseLoadingMap(LogEntry{Message: msg}); ok {
+				t.Errorf("Message %q should NOT have successfully parsed.", msg)
+			}
+		}
+	})
+}
+
+func Test_ParseStartedMap(t *testing.T) {
+	validCases := []struct {
+		msg         string
+		expectedMap string
+	}{
+		{`Started map "de_lltest" (CRC "-25072634")`, "de_lltest"},
+		{`Started map "de_overpass" (CRC "1234")`, "de_overpass"},
+		{`Started map "pl_upward" (CRC "ce4b4a2d3a8d1c2a3e8c1ad9c3f0c1e2")`, "pl_upward"},
+		{`Started map "workshop/125438255/de_cbble" (CRC "-1787457339")`, "workshop/125438255/de_cbble"},
+		{`Started map "c2m1_highway"`, "c2m1_highway"},
+	}
+
+	t.Run("Valid Cases", func(t *testing.T) {
+		for _, test := range validCases {
+			if actualMap, ok := ParseStartedMap(LogEntry{Message: test.msg}); !ok {
+				t.Errorf("Message %q should have successfully parsed.", test.msg)
+			} else if actualMap != test.expectedMap {
+				t.Errorf("Expected map %q but got map %q from message %q.", test.expectedMap, actualMap, test.msg)
+			}
+		}
+	})
+
+	invalidCases := []string{
+		``,
+		`Loading map "de_nuke"`,
+		`World triggered "Match_Start" on "de_lltest"`,
+	}
+
+	t.Run("Invalid Cases", func(t *testing.T) {
+		for _, msg := range invalidCases {
+			if _, ok := ParseStartedMap(LogEntry{Message: msg}); ok {
+				t.Errorf("Message %q should NOT have successfully parsed.", msg)
+			}
+		}
+	})
+}
+
+func Test_parseCvarListEntry(t *testing.T) {
+	validCases := []struct {
+		line     string
+		expected CvarInfo
+	}{
+		{`mp_maxrounds                             : 30       : , "sv", "nf"     : max number of rounds to play before server changes maps`,
+			CvarInfo{Name: "mp_maxrounds", Value: "30", Flags: []string{"sv", "nf"}, Description: "max number of rounds to play before server changes maps"}},
+		{`sv_cheats                                : 0        : , "sv", "nf", "rep" : Allow cheats on server`,
+			CvarInfo{Name: "sv_cheats", Value: "0", Flags: []string{"sv", "nf", "rep"}, Description: "Allow cheats on server"}},
+		{`hostname                                 : Laclede's LAN : , "sv"      : Hostname for server.`,
+			CvarInfo{Name: "hostname", Value: "Laclede's LAN", Flags: []string{"sv"}, Description: "Hostname for server."}},
+		{`changelevel                              : cmd      :                  : Change server to the specified map`,
+			CvarInfo{Name: "changelevel", IsCommand: true, Description: "Change server to the specified map"}},
+		{`+attack                                  : cmd      :                  : `,
+			CvarInfo{Name: "+attack", IsCommand: true}},
+	}
+
+	for _, test := range validCases {
+		actual, ok := parseCvarListEntry(test.line)
+		if !ok {
+			t.Errorf("Line %q should have successfully parsed.", test.line)
+			continue
+		}
+
+		if actual.Name != test.expected.Name || actual.Value != test.expected.Value || actual.IsCommand != test.expected.IsCommand ||
+			actual.Description != test.expected.Description || strings.Join(actual.Flags, ",") != strings.Join(test.expected.Flags, ",") {
+			t.Errorf("Expected %+v not %+v.", test.expected, actual)
+		}
+	}
+
+	invalidCases := []string{`Host_Error: something went wrong`, `sv_stopspeed - 80`, `--------------`, `cvar list`}
+	for _, line := range invalidCases {
+		if _, ok := parseCvarListEntry(line); ok {
+			t.Errorf("Line %q should NOT have successfully parsed.", line)
+		}
+	}
+
+	if total, ok := parseCvarListTotal(`  2373 total convars/concommands`); !ok || total != 2373 {
+		t.Errorf("Expected a total of 2373 not %d.", total)
+	}
+}
@@ -0,0 +1,231 @@
+package srcds
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jsonBlockBegin = "JSON_BEGIN{"
+	jsonBlockEnd   = "}}JSON_END"
+	// jsonBlockMaxLines caps how many lines are buffered waiting for a block to end; a round stats block has a line per player
+	jsonBlockMaxLines = 256
+)
+
+// jsonBlock is a multi-line JSON block being reassembled from the log stream
+type jsonBlock struct {
+	first LogEntry
+	lines []string
+}
+
+// assembleJSONBlock buffers the log entries of a multi-line JSON block; once the block ends it is returned as a single log
+// entry timestamped by its first line. Entries that aren't part of a block are returned as is.
+func (o *Observer) assembleJSONBlock(le LogEntry) (LogEntry, bool) {
+	if strings.HasPrefix(le.Message, jsonBlockBegin) {
+		if o.jsonBlock != nil {
+			log.Warn().Msgf("JSON block started at %v never ended; discarding %d line(s)", o.jsonBlock.first.Timestamp, len(o.jsonBlock.lines))
+		}
+
+		o.jsonBlock = &jsonBlock{first: le, lines: []string{le.Message}}
+		return o.endJSONBlock(le)
+	}
+
+	if o.jsonBlock == nil {
+		return le, true
+	}
+
+	o.jsonBlock.lines = append(o.jsonBlock.lines, le.Message)
+	if len(o.jsonBlock.lines) > jsonBlockMaxLines {
+		log.Warn().Msgf("JSON block started at %v exceeded %d lines; discarding it", o.jsonBlock.first.Timestamp, jsonBlockMaxLines)
+		o.jsonBlock = nil
+		return LogEntry{}, false
+	}
+
+	return o.endJSONBlock(le)
+}
+
+// endJSONBlock returns the reassembled block if the log entry ends the block being buffered
+func (o *Observer) endJSONBlock(le LogEntry) (LogEntry, bool) {
+	if !strings.HasSuffix(le.Message, jsonBlockEnd) {
+		return LogEntry{}, false
+	}
+
+	r := LogEntry{
+		Message:   strings.Join(o.jsonBlock.lines, "\n"),
+		Timestamp: o.jsonBlock.first.Timestamp,
+	}
+	o.jsonBlock = nil
+
+	return r, true
+}
+
+// PlayerRoundStats are a player's statistics as reported at the end of a round
+type PlayerRoundStats struct {
+	SteamID         string // Steam3 ID without brackets (e.g. U:1:12345) to match parsed clients
+	Affiliation     string // CT, TERRORIST or UNASSIGNED
+	Money           int
+	Kills           int
+	Deaths          int
+	Assists         int
+	Damage          int
+	HeadshotPercent float64
+	KDR             float64
+	ADR             float64
+	MVPs            int
+	EnemiesFlashed  int
+	UtilityDamage   int
+	ThreeKills      int
+	FourKills       int
+	FiveKills       int
+	ClutchKills     int
+	FirstKills      int
+	PistolKills     int
+	SniperKills     int
+	BlindKills      int
+	BombKills       int
+	FireDamage      int
+	UniqueKills     int
+	Dinks           int
+	ChickenKills    int
+	Fields          map[string]string // every reported field keyed by name, including those without a typed field
+}
+
+// RoundStats are the per-player statistics printed as a JSON block at the end of every round by newer Counter-Strike builds
+type RoundStats struct {
+	Round          int
+	CTScore        int
+	TerroristScore int
+	Map            string
+	Server         string
+	Players        []PlayerRoundStats
+}
+
+// RoundIndex determines the (zero based) index of the round the stats describe within a match that has completed the
+// specified number of rounds. Stats can be reported before the round they describe has been recorded; those describe the
+// round being played and have an index of completedRounds. False if the stats describe a round that isn't part of the match.
+func (s RoundStats) RoundIndex(completedRounds int) (int, bool) {
+	switch {
+	case s.Round >= 1 && s.Round <= completedRounds:
+		return s.Round - 1, true
+	case s.Round > completedRounds:
+		return completedRounds, true
+	default:
+		return 0, false
+	}
+}
+
+// ParseRoundStats attempts to parse a reassembled round_stats JSON block
+func ParseRoundStats(le LogEntry) (RoundStats, bool) {
+	if !strings.HasPrefix(le.Message, jsonBlockBegin) || !strings.HasSuffix(le.Message, jsonBlockEnd) {
+		return RoundStats{}, false
+	}
+
+	// The block is almost JSON; strip the markers and any trailing commas
+	body := "{" + strings.TrimSuffix(strings.TrimPrefix(le.Message, jsonBlockBegin), "JSON_END")
+	body = stripJSONTrailingCommas(body)
+
+	block := struct {
+		Name        string            `json:"name"`
+		RoundNumber string            `json:"round_number"`
+		ScoreT      string            `json:"score_t"`
+		ScoreCT     string            `json:"score_ct"`
+		Map         string            `json:"map"`
+		Server      string            `json:"server"`
+		Fields      string            `json:"fields"`
+		Players     map[string]string `json:"players"`
+	}{}
+
+	if err := json.Unmarshal([]byte(body), &block); err != nil || block.Name != "round_stats" {
+		return RoundStats{}, false
+	}
+
+	r := RoundStats{Map: block.Map, Server: block.Server}
+	r.Round, _ = strconv.Atoi(strings.TrimSpace(block.RoundNumber))
+	r.TerroristScore, _ = strconv.Atoi(strings.TrimSpace(block.ScoreT))
+	r.CTScore, _ = strconv.Atoi(strings.TrimSpace(block.ScoreCT))
+
+	fields := splitRoundStatsValues(block.Fields)
+
+	// Players are keyed as player_0, player_1, ...; keep them in that order
+	for i := 0; i < len(block.Players); i++ {
+		values, found := block.Players["player_"+strconv.Itoa(i)]
+		if !found {
+			continue
+		}
+
+		p := PlayerRoundStats{Fields: make(map[string]string, len(fields))}
+		for j, v := range splitRoundStatsValues(values) {
+			if j < len(fields) {
+				p.Fields[fields[j]] = v
+			}
+		}
+
+		p.apply()
+		r.Players = append(r.Players, p)
+	}
+
+	return r, true
+}
+
+func splitRoundStatsValues(s string) []string {
+	r := strings.Split(s, ",")
+	for i := range r {
+		r[i] = strings.TrimSpace(r[i])
+	}
+
+	return r
+}
+
+// apply copies the reported fields into their typed counterparts
+func (p *PlayerRoundStats) apply() {
+	atoi := func(name string) int {
+		v, _ := strconv.Atoi(p.Fields[name])
+		return v
+	}
+
+	atof := func(name string) float64 {
+		v, _ := strconv.ParseFloat(p.Fields[name], 64)
+		return v
+	}
+
+	if id := p.Fields["accountid"]; id != "" && id != "0" {
+		p.SteamID = "U:1:" + id
+	}
+
+	switch p.Fields["team"] {
+	case "2":
+		p.Affiliation = "TERRORIST"
+	case "3":
+		p.Affiliation = "CT"
+	default:
+		p.Affiliation = "UNASSIGNED"
+	}
+
+	p.Money = atoi("money")
+	p.Kills = atoi("kills")
+	p.Deaths = atoi("deaths")
+	p.Assists = atoi("assists")
+	p.Damage = atoi("dmg")
+	p.HeadshotPercent = atof("hsp")
+	p.KDR = atof("kdr")
+	p.ADR = atof("adr")
+	p.MVPs = atoi("mvp")
+	p.EnemiesFlashed = atoi("ef")
+	p.UtilityDamage = atoi("ud")
+	p.ThreeKills = atoi("3k")
+	p.FourKills = atoi("4k")
+	p.FiveKills = atoi("5k")
+	p.ClutchKills = atoi("clutchk")
+	p.FirstKills = atoi("firstk")
+	p.PistolKills = atoi("pistolk")
+	p.SniperKills = atoi("sniperk")
+	p.BlindKills = atoi("blindk")
+	p.BombKills = atoi("bombk")
+	p.FireDamage = atoi("firehp")
+	p.UniqueKills = atoi("uniquek")
+	p.Dinks = atoi("dinks")
+	p.ChickenKills = atoi("chickenk")
+}
@@ -0,0 +1,125 @@
+package srcds
+
+import (
+	"strings"
+	"testing"
+)
+
+var roundStatsBlock = []string{
+	`L 10/14/2023 - 18:01:30: JSON_BEGIN{`,
+	`L 10/14/2023 - 18:01:30: "name": "round_stats",`,
+	`L 10/14/2023 - 18:01:30: "round_number" : "3",`,
+	`L 10/14/2023 - 18:01:30: "score_t" : "1",`,
+	`L 10/14/2023 - 18:01:30: "score_ct" : "2",`,
+	`L 10/14/2023 - 18:01:30: "map" : "de_ancient",`,
+	`L 10/14/2023 - 18:01:30: "server" : "Laclede's LAN",`,
+	`L 10/14/2023 - 18:01:30: "fields" : "             accountid,   team,  money,  kills, deaths,assists,    dmg,    hsp,    kdr,    adr,    mvp,     ef,     ud,     3k,     4k,     5k,clutchk, firstk,pistolk,sniperk, blindk,  bombk,firehp,uniquek,  dinks,chickenk,  newk",`,
+	`L 10/14/2023 - 18:01:30: "players" : {`,
+	`L 10/14/2023 - 18:01:30: "player_0" : "        100001,      3,   4150,      4,      1,      1,    412,  50.00,   4.00, 137.33,      2,      3,     24,      1,      0,      0,      0,      1,      1,      0,      0,      0,     8,      4,      1,       0,     7",`,
+	`L 10/14/2023 - 18:01:30: "player_1" : "        100002,      2,    650,      1,      3,      0,    145,   0.00,   0.33,  48.33,      0,      0,      0,      0,      0,      0,      0,      0,      1,      0,      0,      0,     0,      1,      0,       1,     0"`,
+	`L 10/14/2023 - 18:01:30: }}JSON_END`,
+}
+
+func Test_ParseRoundStats(t *testing.T) {
+	messages := []string{}
+	for _, line := range roundStatsBlock {
+		le, _ := parseLogEntry(line)
+		messages = append(messages, le.Message)
+	}
+
+	actual, ok := ParseRoundStats(LogEntry{Message: strings.Join(messages, "\n")})
+	if !ok {
+		t.Fatal("The round stats block should have successfully parsed.")
+	}
+
+	if actual.Round != 3 || actual.CTScore != 2 || actual.TerroristScore != 1 || actual.Map != "de_ancient" || actual.Server != "Laclede's LAN" {
+		t.Errorf("Unexpected round stats %+v.", actual)
+	}
+
+	if len(actual.Players) != 2 {
+		t.Fatalf("Expected stats for 2 players not %d.", len(actual.Players))
+	}
+
+	p := actual.Players[0]
+	if p.SteamID != "U:1:100001" || p.Affiliation != "CT" || p.Money != 4150 || p.Kills != 4 || p.Damage != 412 || p.ADR != 137.33 || p.ThreeKills != 1 || p.FireDamage != 8 {
+		t.Errorf("Unexpected stats for the first player %+v.", p)
+	}
+
+	if p.Fields["newk"] != "7" {
+		t.Errorf("Expected fields without a typed counterpart to be kept not %q.", p.Fields["newk"])
+	}
+
+	if p := actual.Players[1]; p.Affiliation != "TERRORIST" || p.Deaths != 3 || p.ChickenKills != 1 {
+		t.Errorf("Unexpected stats for the second player %+v.", p)
+	}
+
+	invalidCases := []string{
+		`JSON_BEGIN{`,
+		"JSON_BEGIN{\n\"name\": \"match_stats\",\n}}JSON_END",
+		"JSON_BEGIN{\n\"name\": \"round_stats\"\n\"oops\"}}JSON_END",
+	}
+
+	for _, message := range invalidCases {
+		if _, ok := ParseRoundStats(LogEntry{Message: message}); ok {
+			t.Errorf("Message %q should NOT have successfully parsed.", message)
+		}
+	}
+}
+
+func Test_RoundStats_RoundIndex(t *testing.T) {
+	testCases := []struct {
+		round, completed int
+		expected         int
+		expectedOk       bool
+	}{
+		{round: 1, completed: 3, expected: 0, expectedOk: true},
+		{round: 3, completed: 3, expected: 2, expectedOk: true},
+		{round: 4, completed: 3, expected: 3, expectedOk: true},
+		{round: 1, completed: 0, expected: 0, expectedOk: true},
+		{round: 0, completed: 3},
+		{round: -1, completed: 0},
+	}
+
+	for _, test := range testCases {
+		actual, ok := RoundStats{Round: test.round}.RoundIndex(test.completed)
+
+		if ok != test.expectedOk || (ok && actual != test.expected) {
+			t.Errorf("Expected round %d of %d completed rounds to have index %d (%v) not %d (%v).", test.round, test.completed, test.expected, test.expectedOk, actual, ok)
+		}
+	}
+}
+
+func Test_Observer_assembleJSONBlock(t *testing.T) {
+	lines := append([]string{`L 10/14/2023 - 18:01:29: World triggered "Round_End"`}, roundStatsBlock...)
+	lines = append(lines,
+		`L 10/14/2023 - 18:01:31: JSON_BEGIN{`,
+		`L 10/14/2023 - 18:01:31: "name": "round_stats",`,
+		// A block that never ends is discarded once the next one begins
+		`L 10/14/2023 - 18:01:32: JSON_BEGIN{`,
+		`L 10/14/2023 - 18:01:32: }}JSON_END`,
+		`L 10/14/2023 - 18:01:40: World triggered "Round_Start"`,
+	)
+
+	actual := []LogEntry{}
+
+	sut := NewObserver()
+	for le := range sut.Listen(strings.NewReader(strings.Join(lines, "\n") + "\n")) {
+		actual = append(actual, le)
+	}
+
+	if len(actual) != 4 {
+		t.Fatalf("Expected 4 log entries not %d: %+v", len(actual), actual)
+	}
+
+	if stats, ok := ParseRoundStats(actual[1]); !ok || len(stats.Players) != 2 {
+		t.Errorf("Expected the round stats block to be reassembled not %q.", actual[1].Message)
+	}
+
+	if actual[1].Timestamp.Second() != 30 {
+		t.Errorf("Expected the reassembled block to be timestamped by its first line not %v.", actual[1].Timestamp)
+	}
+
+	if actual[2].Message != "JSON_BEGIN{\n}}JSON_END" || actual[3].Message != `World triggered "Round_Start"` {
+		t.Errorf("Expected the empty block followed by the round start not %q and %q.", actual[2].Message, actual[3].Message)
+	}
+}
@@ -23,6 +23,8 @@ type Server struct {
 	*Observer
 	process *exec.Cmd
 	cmdIn   chan string
+	health  *healthTracker
+	locks   cvarLocks
 	wg      sync.WaitGroup
 }
 
@@ -31,8 +33,25 @@ func NewServer() *Server {
 	s := &Server{
 		Observer: NewObserver(),
 		cmdIn:    make(chan string, 4),
+		health:   newHealthTracker(),
 	}
 
+	s.locks.enforce = true
+	s.OnCvarChange(s.checkCvarLock)
+
+	parsers := []LineParser{
+		{Name: "rcon_command", Prefix: "rcon from ", Handler: s.recordRconCommand},
+		{Name: "map_status", Regex: mapStatusRegex, Handler: s.recordMapStatus},
+	}
+
+	for _, p := range parsers {
+		if err := s.RegisterParser(p); err != nil {
+			log.Error().Err(err).Str("parser", p.Name).Msg("Couldn't register SRCDS server parser")
+		}
+	}
+
+	s.OnConsoleLine(s.health.consoleLine)
+
 	return s
 }
 
@@ -42,10 +61,11 @@ func (s *Server) SetExec(path string, args ...string) error {
 
 	go func(cancel context.CancelFunc) {
 		/// TODO: add back in safety requiring signal to be sent twice in x seconds?
-		sig := make(chan os.Signal)
-		signal.Notify(sig, os.Interrupt, syscall.SIGINT)
+		sig := make(chan os.Signal, 1)
+		// SIGTERM is how container runtimes ask for a stop
+		signal.Notify(sig, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
 		defer cancel()
-		defer close(sig)
+		defer signal.Stop(sig)
 
 		<-sig
 	}(cancel)
@@ -144,6 +164,29 @@ func (s *Server) SendCommand(l string) {
 	}
 }
 
+// QueueCommand sends a command to the interactive SRCDS instance unless it exits first, returning if it was queued
+func (s *Server) QueueCommand(l string) bool {
+	l = strings.TrimSpace(l)
+	if len(l) == 0 {
+		return false
+	}
+
+	select {
+	case <-s.health.done:
+		log.Warn().Msgf("Command %q wasn't sent as SRCDS stopped", l)
+		return false
+	default:
+	}
+
+	select {
+	case s.cmdIn <- l:
+		return true
+	case <-s.health.done:
+		log.Warn().Msgf("Command %q wasn't sent as SRCDS stopped", l)
+		return false
+	}
+}
+
 // Listen starts the SRCDS server, processes its output, and returns its log stream
 func (s *Server) Listen() (<-chan LogEntry, error) {
 	if s.process == nil {
@@ -160,8 +203,28 @@ func (s *Server) Listen() (<-chan LogEntry, error) {
 	}
 
 	log.Debug().Msg("Server execution started")
+	s.health.start(time.Now())
+
+	in := s.Observer.Listen(outputRecorder{r: cmdStdOut, health: s.health})
+	out := make(chan LogEntry, 6)
+
+	go func() {
+		defer close(out)
+		for le := range in {
+			out <- le
+		}
+
+		// The process may only be waited on once its output has been read
+		err := s.process.Wait()
+		if err != nil {
+			log.Warn().Err(err).Msg("Server execution stopped")
+		} else {
+			log.Debug().Msg("Server execution stopped")
+		}
+		s.health.exit(time.Now(), err)
+	}()
 
-	return s.Observer.Listen(cmdStdOut), nil
+	return out, nil
 }
 
 // Read starts the SRCDS server and processes its output
@@ -0,0 +1,5 @@
+/*
+Package tf2 provides functionality for tailing, parsing, and interpreting <a href="https://www.teamfortress.com/">Team Fortress 2</a> dedicated server logging along with constructs
+for automating wrapped, executing tf2 server processes.
+*/
+package tf2
@@ -0,0 +1,152 @@
+package tf2
+
+import (
+	"strings"
+	"time"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+	"github.com/rs/zerolog/log"
+)
+
+// affiliation represents a player's team color; TF2 teams keep their color for the entire match
+type affiliation string
+
+const (
+	unassigned affiliation = "Unassigned"
+	red        affiliation = "Red"
+	blue       affiliation = "Blue"
+	spectator  affiliation = "Spectator"
+)
+
+// matchInfo contains statistics about a match
+type matchInfo struct {
+	current   roundInfo
+	ended     time.Time
+	mapName   string
+	players   map[string]*playerInfo // keyed by playerKey
+	roundLive bool
+	rounds    []roundInfo
+	scores    map[affiliation]int // as reported by the server; rounds won otherwise
+	started   time.Time
+}
+
+// roundInfo contains statistics about a round
+type roundInfo struct {
+	captures []captureInfo
+	ended    time.Time
+	started  time.Time
+	winner   affiliation // empty for a stalemate
+}
+
+type captureInfo struct {
+	at          time.Time
+	affiliation affiliation
+	cp          int
+	cpName      string
+	cappers     []string // player keys
+}
+
+type playerInfo struct {
+	affiliation affiliation
+	captures    int
+	class       string
+	deaths      int
+	kills       int
+	username    string
+}
+
+type gameInfo struct {
+	matches      []matchInfo
+	events       srcds.EventQueue
+	redTeamName  string
+	blueTeamName string
+	// awaitingReady is set from the map starting (or the tournament restarting) until tournament mode starts, which it
+	// only does once both teams are ready
+	awaitingReady bool
+	mapName       string
+}
+
+// currentMatch returns the current match, starting one if none has been observed
+func (g *gameInfo) currentMatch(at time.Time) *matchInfo {
+	if len(g.matches) == 0 {
+		g.nextMatch(at)
+	}
+
+	return &g.matches[len(g.matches)-1]
+}
+
+// nextMatch will end the current match and start the next; a current match without any completed rounds is reused.
+// Players keep their team and class but their statistics are reset.
+func (g *gameInfo) nextMatch(at time.Time) {
+	next := newMatchInfo(g.mapName, at)
+
+	if n := len(g.matches); n > 0 {
+		for id, p := range g.matches[n-1].players {
+			next.players[id] = &playerInfo{affiliation: p.affiliation, class: p.class, username: p.username}
+		}
+	}
+
+	if n := len(g.matches); n > 0 && len(g.matches[n-1].rounds) == 0 {
+		g.matches[n-1] = next
+		return
+	}
+
+	if n := len(g.matches); n > 0 && g.matches[n-1].ended.IsZero() {
+		g.matches[n-1].ended = at
+	}
+
+	g.matches = append(g.matches, next)
+	log.Info().Msgf("Match %02d starting on map %q", len(g.matches), g.mapName)
+}
+
+func newMatchInfo(mapName string, at time.Time) matchInfo {
+	return matchInfo{
+		mapName: mapName,
+		players: make(map[string]*playerInfo),
+		scores:  make(map[affiliation]int),
+		started: at,
+	}
+}
+
+// isPayload determines if the current map is a payload (or payload race) map
+func (g *gameInfo) isPayload() bool {
+	return strings.HasPrefix(g.mapName, "pl_") || strings.HasPrefix(g.mapName, "plr_")
+}
+
+func (g *gameInfo) teamName(aff affiliation) string {
+	switch aff {
+	case red:
+		return g.redTeamName
+	case blue:
+		return g.blueTeamName
+	default:
+		return ""
+	}
+}
+
+// playerKey returns the key used to track a client's statistics; bots share a SteamID so their username is used instead
+func playerKey(c srcds.Client) string {
+	if c.IsBot() {
+		return "BOT:" + c.Username
+	}
+
+	return c.SteamID
+}
+
+// player returns the statistics of a player in the current match
+func (m *matchInfo) player(c srcds.Client) *playerInfo {
+	key := playerKey(c)
+
+	p, found := m.players[key]
+	if !found {
+		p = &playerInfo{}
+		m.players[key] = p
+	}
+
+	p.username = c.Username
+	if aff, ok := parseAffiliation(c.Affiliation); ok && aff != unassigned {
+		p.affiliation = aff
+	}
+
+	return p
+}
@@ -0,0 +1,403 @@
+package tf2
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultMpTournament int = 0
+)
+
+// ClassChanged is emitted when a player changes class
+type ClassChanged struct {
+	srcds.EventTime
+	Match    int
+	SteamID  string
+	Username string
+	Team     string
+	Class    string
+}
+
+// Killed is emitted when a player kills another player
+type Killed struct {
+	srcds.EventTime
+	Match            int
+	AttackerSteamID  string
+	AttackerUsername string
+	VictimSteamID    string
+	VictimUsername   string
+	Weapon           string
+	CustomKill       string // e.g. headshot or backstab
+}
+
+// MatchEnded is emitted when the game is over
+type MatchEnded struct {
+	srcds.EventTime
+	Match     int
+	Reason    string // e.g. "Reached Win Limit" or "Reached Time Limit"
+	Winner    string // Red or Blue; empty if the teams are tied
+	RedScore  int
+	BlueScore int
+}
+
+// PayloadProgress is emitted when a payload cart is pushed through a checkpoint
+type PayloadProgress struct {
+	srcds.EventTime
+	Match      int
+	Round      int
+	Team       string
+	Checkpoint int // checkpoints reached by the team this round, starting at 1
+}
+
+// PointCaptured is emitted when a team captures a control point
+type PointCaptured struct {
+	srcds.EventTime
+	Match    int
+	Round    int
+	Team     string
+	Point    int
+	Name     string
+	Cappers  []string // SteamIDs; bots are "BOT:" and their username
+	TeamName string
+}
+
+// RoundEnded is emitted when a round is won or ends in a stalemate
+type RoundEnded struct {
+	srcds.EventTime
+	Match  int
+	Round  int
+	Winner string // Red or Blue; empty for a stalemate
+}
+
+// TeamsReady is emitted when tournament mode starts after both teams readied up, right before the first round
+type TeamsReady struct {
+	srcds.EventTime
+	Match int
+}
+
+// NewObserver for observing TF2 log streams
+func NewObserver(mpTournament int) *Observer {
+	o := &Observer{
+		srcdsObserver: srcds.NewObserver(),
+	}
+
+	o.srcdsObserver.AddCvarWatcherDefault("mp_tournament", strconv.Itoa(mpTournament))
+
+	return o
+}
+
+// Read a TF2 log output stream
+func (o *Observer) Read(r io.Reader) {
+	o.waitGroup.Add(1)
+	go func() {
+		defer o.waitGroup.Done()
+		for range o.Listen(r) {
+		}
+	}()
+}
+
+// Listen to a TF2 log output stream; the stream must be drained as the observer blocks while it is full,
+// consumers that may fall behind should Subscribe instead
+func (o *Observer) Listen(r io.Reader) <-chan srcds.LogEntry {
+	logStream := make(chan srcds.LogEntry, 6)
+	o.waitGroup.Add(1)
+
+	go func(l chan<- srcds.LogEntry) {
+		defer close(l)
+		defer o.waitGroup.Done()
+		for le := range o.srcdsObserver.Listen(r) {
+			o.processLogEntry(le)
+			l <- le
+		}
+	}(logStream)
+
+	return logStream
+}
+
+// Wait for the TF2 observer to exit naturally.
+func (o *Observer) Wait() {
+	o.waitGroup.Wait()
+}
+
+// RegisterParser adds a parser that is offered every log entry before the TF2 observer processes it; parsers
+// registered while the log stream is being observed are offered the log entries that follow
+func (o *Observer) RegisterParser(p srcds.LineParser) error {
+	return o.srcdsObserver.RegisterParser(p)
+}
+
+// Subscribe to the log stream; subscribers receive each log entry before the TF2 observer processes it
+func (o *Observer) Subscribe(name string, size int, policy srcds.OverflowPolicy) *srcds.Subscription {
+	return o.srcdsObserver.Subscribe(name, size, policy)
+}
+
+// Observer for watching TF2 log streams
+type Observer struct {
+	game          gameInfo
+	mux           sync.Mutex
+	srcdsObserver *srcds.Observer
+	waitGroup     sync.WaitGroup
+}
+
+// AwaitingReady determines if tournament mode is waiting on both teams to ready up
+func (o *Observer) AwaitingReady() bool {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	return o.isTournament() && o.game.awaitingReady
+}
+
+// processLogEntry and apply it to TF2
+func (o *Observer) processLogEntry(le srcds.LogEntry) {
+	o.mux.Lock()
+	o.applyLogEntry(le)
+	o.mux.Unlock()
+
+	o.game.events.Dispatch()
+}
+
+// applyLogEntry updates the observed game state; the caller must hold the observer's lock
+func (o *Observer) applyLogEntry(le srcds.LogEntry) {
+	if clientLog, ok := srcds.ParseClientLogEntry(le); ok {
+		o.applyClientLogEntry(le.Timestamp, clientLog)
+		return
+	}
+
+	if parseRoundStart(le) {
+		o.startRound(le.Timestamp)
+		return
+	}
+
+	if winner, ok := parseRoundWin(le); ok {
+		o.endRound(le.Timestamp, winner)
+		return
+	}
+
+	if parseRoundStalemate(le) {
+		o.endRound(le.Timestamp, "")
+		return
+	}
+
+	if m, ok := parsePointCaptured(le); ok {
+		o.recordCapture(le.Timestamp, m)
+		return
+	}
+
+	if m, ok := parseTeamScore(le); ok {
+		o.game.currentMatch(le.Timestamp).scores[m.affiliation] = m.score
+		return
+	}
+
+	if reason, ok := parseGameOver(le); ok {
+		o.endMatch(le.Timestamp, reason)
+		return
+	}
+
+	if parseTournamentStarted(le) {
+		o.game.nextMatch(le.Timestamp)
+		o.teamsReady(le.Timestamp)
+		return
+	}
+
+	if parseTournamentRestart(le.Message) {
+		log.Info().Msg("Tournament restarted; waiting for both teams to ready up")
+		o.game.awaitingReady = true
+		o.game.nextMatch(le.Timestamp)
+		return
+	}
+
+	if m, ok := parseTournamentTeamName(le); ok {
+		o.setTeamname(m.affiliation, m.teamName)
+		return
+	}
+
+	mapName, ok := srcds.ParseLoadingMap(le)
+	if !ok {
+		mapName, ok = srcds.ParseStartedMap(le)
+	}
+
+	if ok {
+		// Tournaments wait for both teams to ready up on every map
+		o.game.awaitingReady = true
+
+		if o.game.mapName != mapName {
+			o.game.mapName = mapName
+			o.game.nextMatch(le.Timestamp)
+		}
+	}
+}
+
+// applyClientLogEntry updates the observed game state from a player's action
+func (o *Observer) applyClientLogEntry(at time.Time, clientLog srcds.ClientLogEntry) {
+	m := o.game.currentMatch(at)
+
+	if k, ok := parseClientKilled(clientLog); ok {
+		m.player(clientLog.Client).kills++
+		m.player(k.victim).deaths++
+
+		o.game.emit(Killed{
+			EventTime:        srcds.EventTime{At: at},
+			Match:            len(o.game.matches),
+			AttackerSteamID:  clientLog.Client.SteamID,
+			AttackerUsername: clientLog.Client.Username,
+			VictimSteamID:    k.victim.SteamID,
+			VictimUsername:   k.victim.Username,
+			Weapon:           k.weapon,
+			CustomKill:       k.customKill,
+		})
+		return
+	}
+
+	if class, ok := parseClientChangedRole(clientLog); ok {
+		p := m.player(clientLog.Client)
+		if p.class == class {
+			return
+		}
+
+		p.class = class
+		o.game.emit(ClassChanged{
+			EventTime: srcds.EventTime{At: at},
+			Match:     len(o.game.matches),
+			SteamID:   clientLog.Client.SteamID,
+			Username:  clientLog.Client.Username,
+			Team:      string(p.affiliation),
+			Class:     class,
+		})
+		return
+	}
+
+	if aff, ok := parseClientJoinedTeam(clientLog); ok {
+		m.player(clientLog.Client).affiliation = aff
+		log.Info().Str("SteamID", clientLog.Client.SteamID).Msgf("Client %q joined %v.", clientLog.Client.Username, aff)
+	}
+}
+
+// isTournament determines if the server is running in tournament mode
+func (o *Observer) isTournament() bool {
+	v, _ := o.srcdsObserver.TryCvarAsInt("mp_tournament", defaultMpTournament)
+	return v != 0
+}
+
+// startRound begins tracking a new round
+func (o *Observer) startRound(at time.Time) {
+	m := o.game.currentMatch(at)
+	m.current = roundInfo{started: at}
+	m.roundLive = true
+
+	log.Info().Msg("Round Start")
+}
+
+// teamsReady records both teams readying up; tournament mode starts (naming both teams) right before the first round
+func (o *Observer) teamsReady(at time.Time) {
+	o.game.awaitingReady = false
+
+	log.Info().Int("match", len(o.game.matches)).Msg("Tournament mode started; both teams are ready")
+	o.game.emit(TeamsReady{EventTime: srcds.EventTime{At: at}, Match: len(o.game.matches)})
+}
+
+// endRound records the winner of the current round; an empty winner is a stalemate
+func (o *Observer) endRound(at time.Time, winner affiliation) {
+	m := o.game.currentMatch(at)
+
+	round := m.current
+	round.ended, round.winner = at, winner
+	m.rounds = append(m.rounds, round)
+	m.current = roundInfo{}
+	m.roundLive = false
+
+	if winner != "" {
+		// Reported scores are authoritative; the server follows up with the current score of both teams
+		m.scores[winner]++
+	}
+
+	log.Info().Int("match", len(o.game.matches)).Int("round", len(m.rounds)).Int("red_score", m.scores[red]).Int("blue_score", m.scores[blue]).
+		Msgf("Round %02d won by %v", len(m.rounds), winner)
+
+	o.game.emit(RoundEnded{EventTime: srcds.EventTime{At: at}, Match: len(o.game.matches), Round: len(m.rounds), Winner: string(winner)})
+}
+
+// recordCapture records a control point (or payload checkpoint) captured during the current round
+func (o *Observer) recordCapture(at time.Time, msg pointCaptured) {
+	m := o.game.currentMatch(at)
+	capture := captureInfo{at: at, affiliation: msg.affiliation, cp: msg.cp, cpName: msg.cpName}
+
+	for _, capper := range msg.cappers {
+		m.player(capper).captures++
+		capture.cappers = append(capture.cappers, playerKey(capper))
+	}
+
+	m.current.captures = append(m.current.captures, capture)
+	round := len(m.rounds) + 1
+
+	o.game.emit(PointCaptured{
+		EventTime: srcds.EventTime{At: at},
+		Match:     len(o.game.matches),
+		Round:     round,
+		Team:      string(msg.affiliation),
+		Point:     msg.cp,
+		Name:      msg.cpName,
+		Cappers:   capture.cappers,
+		TeamName:  o.game.teamName(msg.affiliation),
+	})
+
+	if !o.game.isPayload() {
+		return
+	}
+
+	checkpoint := 0
+	for _, c := range m.current.captures {
+		if c.affiliation == msg.affiliation {
+			checkpoint++
+		}
+	}
+
+	log.Info().Int("match", len(o.game.matches)).Int("round", round).Msgf("%v pushed the payload through checkpoint %d", msg.affiliation, checkpoint)
+	o.game.emit(PayloadProgress{EventTime: srcds.EventTime{At: at}, Match: len(o.game.matches), Round: round, Team: string(msg.affiliation), Checkpoint: checkpoint})
+}
+
+// endMatch marks the current match as over
+func (o *Observer) endMatch(at time.Time, reason string) {
+	m := o.game.currentMatch(at)
+	m.ended = at
+
+	winner := ""
+	switch {
+	case m.scores[red] > m.scores[blue]:
+		winner = string(red)
+	case m.scores[blue] > m.scores[red]:
+		winner = string(blue)
+	}
+
+	log.Info().Int("match", len(o.game.matches)).Int("red_score", m.scores[red]).Int("blue_score", m.scores[blue]).Msgf("Game over (%v)", reason)
+
+	o.game.emit(MatchEnded{
+		EventTime: srcds.EventTime{At: at},
+		Match:     len(o.game.matches),
+		Reason:    reason,
+		Winner:    winner,
+		RedScore:  m.scores[red],
+		BlueScore: m.scores[blue],
+	})
+}
+
+func (o *Observer) setTeamname(aff affiliation, name string) {
+	name = strings.TrimSpace(name)
+
+	switch aff {
+	case red:
+		o.game.redTeamName = name
+	case blue:
+		o.game.blueTeamName = name
+	default:
+		log.Warn().Msgf("Cannot set a team name of %q for affiliation %q.", name, aff)
+		return
+	}
+
+	log.Info().Msgf("Team %q is playing as %v", name, aff)
+}
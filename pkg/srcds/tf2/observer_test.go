@@ -0,0 +1,158 @@
+package tf2
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+)
+
+func Test_Observer_payloadTournament(t *testing.T) {
+	file, err := os.Open("./testdata/pl_upward_tournament.log")
+	if err != nil {
+		t.Fatalf("Could not open log file: %v", err)
+	}
+	defer file.Close()
+
+	actual := []string{}
+
+	sut := NewObserver(1)
+	sut.OnEvent(func(e srcds.Event) {
+		switch v := e.(type) {
+		case ClassChanged:
+			actual = append(actual, fmt.Sprintf("%s plays %s", v.Username, v.Class))
+		case Killed:
+			actual = append(actual, fmt.Sprintf("%s killed %s (%s)", v.AttackerUsername, v.VictimUsername, v.Weapon))
+		case MatchEnded:
+			actual = append(actual, fmt.Sprintf("game over: %s wins %d-%d", v.Winner, v.BlueScore, v.RedScore))
+		case PayloadProgress:
+			actual = append(actual, fmt.Sprintf("%s checkpoint %d", v.Team, v.Checkpoint))
+		case PointCaptured:
+			actual = append(actual, fmt.Sprintf("%s captured %s", v.TeamName, v.Name))
+		case RoundEnded:
+			actual = append(actual, fmt.Sprintf("round %d won by %q", v.Round, v.Winner))
+		case TeamsReady:
+			actual = append(actual, "ready")
+		}
+	})
+	sut.Read(file)
+	sut.Wait()
+
+	expected := []string{
+		"Alpha plays scout",
+		"Xray plays soldier",
+		"ready",
+		"Alpha killed Xray (scattergun)",
+		"Laclede captured #Upward_cap_1",
+		"Blue checkpoint 1",
+		"Xray plays demoman",
+		"Xray killed Alpha (tf_projectile_pipe)",
+		"Laclede captured #Upward_cap_2",
+		"Blue checkpoint 2",
+		`round 1 won by "Blue"`,
+		`round 2 won by ""`,
+		"game over: Blue wins 1-0",
+	}
+
+	if len(actual) != len(expected) {
+		t.Fatalf("Expected events %q not %q.", expected, actual)
+	}
+
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("Expected event %q not %q.", expected[i], actual[i])
+		}
+	}
+
+	if sut.AwaitingReady() {
+		t.Error("Expected the tournament not to be waiting on teams once tournament mode started.")
+	}
+
+	m, _ := sut.CurrentMatch()
+	if m.MapName != "pl_upward" || len(m.Rounds) != 2 || len(m.Rounds[0].Captures) != 2 || m.Ended.IsZero() {
+		t.Errorf("Unexpected match snapshot %+v.", m)
+	}
+
+	if p := m.Players["U:1:1001"]; p.Kills != 1 || p.Deaths != 1 || p.Captures != 2 || p.Class != "scout" || p.Team != "Blue" {
+		t.Errorf("Unexpected stats for Alpha %+v.", p)
+	}
+
+	if p := m.Players["U:1:2001"]; p.Class != "demoman" || p.Team != "Red" {
+		t.Errorf("Unexpected stats for Xray %+v.", p)
+	}
+}
+
+func Test_Observer_awaitingReady(t *testing.T) {
+	ready := 0
+
+	sut := NewObserver(1)
+	sut.OnEvent(func(e srcds.Event) {
+		if _, ok := e.(TeamsReady); ok {
+			ready++
+		}
+	})
+
+	steps := []struct {
+		message          string
+		expectedAwaiting bool
+		expectedReady    int
+	}{
+		{`Loading map "pl_upward"`, true, 0},
+		{`Started map "pl_upward" (CRC "ce4b4a2d3a8d1c2a3e8c1ad9c3f0c1e2")`, true, 0},
+		{`Tournament mode started`, false, 1},
+		{`Blue Team: Laclede`, false, 1},
+		{`World triggered "Round_Start"`, false, 1},
+		{`rcon from "192.168.1.10:50123": command "mp_tournament_restart"`, true, 1},
+		{`World triggered "Round_Start"`, true, 1},
+		{`Tournament mode started`, false, 2},
+	}
+
+	for _, step := range steps {
+		sut.processLogEntry(srcds.LogEntry{Message: step.message})
+
+		if actual := sut.AwaitingReady(); actual != step.expectedAwaiting {
+			t.Errorf("After %q expected awaiting ready to be %v.", step.message, step.expectedAwaiting)
+		}
+
+		if ready != step.expectedReady {
+			t.Errorf("After %q expected the teams to have readied up %d times not %d.", step.message, step.expectedReady, ready)
+		}
+	}
+
+	casual := NewObserver(0)
+	casual.processLogEntry(srcds.LogEntry{Message: `Started map "pl_upward" (CRC "ce4b4a2d3a8d1c2a3e8c1ad9c3f0c1e2")`})
+
+	if casual.AwaitingReady() {
+		t.Error("Expected a server that isn't in tournament mode NOT to be waiting on teams to ready up.")
+	}
+}
+
+func Test_Observer_bots(t *testing.T) {
+	stream := strings.Join([]string{
+		`L 06/21/2020 - 19:49:30: Loading map "pl_upward"`,
+		`L 06/21/2020 - 19:49:33: Started map "pl_upward" (CRC "ce4b4a2d3a8d1c2a3e8c1ad9c3f0c1e2")`,
+		`L 06/21/2020 - 19:50:12: "Bot01<2><BOT><Unassigned>" joined team "Blue"`,
+		`L 06/21/2020 - 19:50:12: "Bot02<3><BOT><Unassigned>" joined team "Red"`,
+		`L 06/21/2020 - 19:51:55: World triggered "Round_Start"`,
+		`L 06/21/2020 - 19:53:30: "Bot01<2><BOT><Blue>" killed "Bot02<3><BOT><Red>" with "scattergun" (attacker_position "-1 2 3") (victim_position "4 5 6")`,
+	}, "\n") + "\n"
+
+	sut := NewObserver(0)
+	sut.Read(strings.NewReader(stream))
+	sut.Wait()
+
+	m, _ := sut.CurrentMatch()
+	if len(m.Players) != 2 {
+		t.Fatalf("Expected each bot to be tracked separately not %+v.", m.Players)
+	}
+
+	if p := m.Players["BOT:Bot01"]; p.Username != "Bot01" || p.Kills != 1 || p.Deaths != 0 || p.Team != "Blue" {
+		t.Errorf("Unexpected stats for Bot01 %+v.", p)
+	}
+
+	if p := m.Players["BOT:Bot02"]; p.Username != "Bot02" || p.Kills != 0 || p.Deaths != 1 || p.Team != "Red" {
+		t.Errorf("Unexpected stats for Bot02 %+v.", p)
+	}
+}
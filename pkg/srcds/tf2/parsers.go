@@ -0,0 +1,210 @@
+package tf2
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+)
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+func parseAffiliation(af string) (affiliation affiliation, ok bool) {
+	switch a := strings.ToUpper(strings.TrimSpace(af)); a {
+	case "RED":
+		return red, true
+	case "BLUE":
+		return blue, true
+	case "SPECTATOR":
+		return spectator, true
+	case "", "UNASSIGNED":
+		return unassigned, true
+	}
+
+	return unassigned, false
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+var clientChangedRoleRegex = regexp.MustCompile(`^changed role to "(\w+)"$`)
+
+func parseClientChangedRole(clientLog srcds.ClientLogEntry) (class string, ok bool) {
+	tokens := clientChangedRoleRegex.FindStringSubmatch(clientLog.Message)
+
+	if len(tokens) != 2 {
+		return "", false
+	}
+
+	return tokens[1], true
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+var clientJoinedTeamRegex = regexp.MustCompile(`^joined team "(\w+)"$`)
+
+func parseClientJoinedTeam(clientLog srcds.ClientLogEntry) (affiliation, bool) {
+	tokens := clientJoinedTeamRegex.FindStringSubmatch(clientLog.Message)
+
+	if len(tokens) != 2 {
+		return unassigned, false
+	}
+
+	return parseAffiliation(tokens[1])
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+var clientKilledRegex = regexp.MustCompile(`^killed (".+?<\d*><[^>]*><\w*>") with "([\w-]+)"(?: \(customkill "(\w+)"\))?`)
+
+// clientKilled is sent when a player kills another player
+type clientKilled struct {
+	victim     srcds.Client
+	weapon     string
+	customKill string // e.g. headshot or backstab
+}
+
+func parseClientKilled(clientLog srcds.ClientLogEntry) (clientKilled, bool) {
+	tokens := clientKilledRegex.FindStringSubmatch(clientLog.Message)
+
+	if len(tokens) != 4 {
+		return clientKilled{}, false
+	}
+
+	victim, ok := srcds.ParseClient(tokens[1])
+	if !ok {
+		return clientKilled{}, false
+	}
+
+	return clientKilled{victim: victim, weapon: tokens[2], customKill: tokens[3]}, true
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+var gameOverRegex = regexp.MustCompile(`^World triggered "Game_Over" reason "(.*)"$`)
+
+func parseGameOver(le srcds.LogEntry) (reason string, ok bool) {
+	tokens := gameOverRegex.FindStringSubmatch(le.Message)
+
+	if len(tokens) != 2 {
+		return "", false
+	}
+
+	return tokens[1], true
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+var (
+	pointCapturedRegex       = regexp.MustCompile(`^Team "(Red|Blue)" triggered "pointcaptured" \(cp "(\d+)"\) \(cpname "([^"]*)"\) \(numcappers "(\d+)"\)(.*)$`)
+	pointCapturedPlayerRegex = regexp.MustCompile(`\(player\d+ ("[^"]+?<\d*><[^>]*><\w*>")\)`)
+)
+
+// pointCaptured is sent when a team captures a control point (or pushes a payload cart through a checkpoint)
+type pointCaptured struct {
+	affiliation affiliation
+	cp          int
+	cpName      string
+	cappers     []srcds.Client
+}
+
+func parsePointCaptured(le srcds.LogEntry) (pointCaptured, bool) {
+	tokens := pointCapturedRegex.FindStringSubmatch(le.Message)
+
+	if len(tokens) != 6 {
+		return pointCaptured{}, false
+	}
+
+	r := pointCaptured{}
+	r.affiliation, _ = parseAffiliation(tokens[1])
+	r.cp, _ = strconv.Atoi(tokens[2])
+	r.cpName = tokens[3]
+
+	for _, p := range pointCapturedPlayerRegex.FindAllStringSubmatch(tokens[5], -1) {
+		if c, ok := srcds.ParseClient(p[1]); ok {
+			r.cappers = append(r.cappers, c)
+		}
+	}
+
+	return r, true
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+func parseRoundStalemate(le srcds.LogEntry) (ok bool) {
+	return le.Message == `World triggered "Round_Stalemate"`
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+func parseRoundStart(le srcds.LogEntry) (ok bool) {
+	return le.Message == `World triggered "Round_Start"`
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+var roundWinRegex = regexp.MustCompile(`^World triggered "Round_Win" \(winner "(Red|Blue)"\)$`)
+
+func parseRoundWin(le srcds.LogEntry) (winner affiliation, ok bool) {
+	tokens := roundWinRegex.FindStringSubmatch(le.Message)
+
+	if len(tokens) != 2 {
+		return unassigned, false
+	}
+
+	return parseAffiliation(tokens[1])
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+var teamScoreRegex = regexp.MustCompile(`^Team "(Red|Blue)" (current|final) score "(\d+)" with "(\d+)" players$`)
+
+// teamScore is sent for both teams after every round and again once the game is over
+type teamScore struct {
+	affiliation affiliation
+	final       bool
+	score       int
+	playerCount int
+}
+
+func parseTeamScore(le srcds.LogEntry) (teamScore, bool) {
+	tokens := teamScoreRegex.FindStringSubmatch(le.Message)
+
+	if len(tokens) != 5 {
+		return teamScore{}, false
+	}
+
+	r := teamScore{}
+	r.playerCount, _ = strconv.Atoi(tokens[4])
+	r.score, _ = strconv.Atoi(tokens[3])
+	r.final = tokens[2] == "final"
+	r.affiliation, _ = parseAffiliation(tokens[1])
+
+	return r, true
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+var tournamentRestartRegex = regexp.MustCompile(`(?:^|[\s"])mp_tournament_restart(?:$|[\s";])`)
+
+// parseTournamentRestart determines if a line contains a command returning a tournament to the ready-up stage
+func parseTournamentRestart(line string) (ok bool) {
+	return tournamentRestartRegex.MatchString(line)
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+func parseTournamentStarted(le srcds.LogEntry) (ok bool) {
+	return le.Message == "Tournament mode started"
+}
+
+///////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+var tournamentTeamNameRegex = regexp.MustCompile(`^(Red|Blue) Team: (.+)$`)
+
+// teamSetName is sent for both teams as tournament mode starts
+type teamSetName struct {
+	affiliation affiliation
+	teamName    string
+}
+
+func parseTournamentTeamName(le srcds.LogEntry) (teamSetName, bool) {
+	tokens := tournamentTeamNameRegex.FindStringSubmatch(le.Message)
+
+	if len(tokens) != 3 {
+		return teamSetName{}, false
+	}
+
+	r := teamSetName{}
+	r.teamName = strings.TrimSpace(tokens[2])
+	r.affiliation, _ = parseAffiliation(tokens[1])
+
+	return r, true
+}
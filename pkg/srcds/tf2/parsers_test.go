@@ -0,0 +1,145 @@
+package tf2
+
+import (
+	"testing"
+
+	"github.com/lacledeslan/sourceseer/pkg/srcds"
+)
+
+func clientLog(t *testing.T, message string) srcds.ClientLogEntry {
+	t.Helper()
+
+	cle, ok := srcds.ParseClientLogEntry(srcds.LogEntry{Message: message})
+	if !ok {
+		t.Fatalf("Message %q should have been a client log entry.", message)
+	}
+
+	return cle
+}
+
+func Test_parseAffiliation(t *testing.T) {
+	validCases := map[string]affiliation{``: unassigned, `Red`: red, ` blue`: blue, `Spectator`: spectator, `Unassigned`: unassigned}
+
+	for input, expected := range validCases {
+		if actual, ok := parseAffiliation(input); !ok || actual != expected {
+			t.Errorf("Expected affiliation %q to parse as %q not %q.", input, expected, actual)
+		}
+	}
+
+	if _, ok := parseAffiliation("CT"); ok {
+		t.Error("Affiliation CT should NOT have successfully parsed.")
+	}
+}
+
+func Test_parseClientChangedRole(t *testing.T) {
+	if actual, ok := parseClientChangedRole(clientLog(t, `"Alpha<2><[U:1:1001]><Blue>" changed role to "heavyweapons"`)); !ok || actual != "heavyweapons" {
+		t.Errorf("Expected the class to be heavyweapons not %q.", actual)
+	}
+
+	if _, ok := parseClientChangedRole(clientLog(t, `"Alpha<2><[U:1:1001]><Blue>" changed name to "Bravo"`)); ok {
+		t.Error("A name change should NOT have successfully parsed.")
+	}
+}
+
+func Test_parseClientJoinedTeam(t *testing.T) {
+	if actual, ok := parseClientJoinedTeam(clientLog(t, `"Alpha<2><[U:1:1001]><Unassigned>" joined team "Red"`)); !ok || actual != red {
+		t.Errorf("Expected the team to be Red not %q.", actual)
+	}
+
+	if _, ok := parseClientJoinedTeam(clientLog(t, `"Alpha<2><[U:1:1001]><Unassigned>" joined team "Purple"`)); ok {
+		t.Error("An unknown team should NOT have successfully parsed.")
+	}
+}
+
+func Test_parseClientKilled(t *testing.T) {
+	validCases := []struct {
+		message  string
+		victim   string
+		weapon   string
+		custom   string
+		victimID string
+	}{
+		{`"Alpha<2><[U:1:1001]><Blue>" killed "Xray<3><[U:1:2001]><Red>" with "scattergun" (attacker_position "-1 2 3") (victim_position "4 5 6")`, "Xray", "scattergun", "", "U:1:2001"},
+		{`"Alpha<2><[U:1:1001]><Blue>" killed "Bot <Heavy><4><BOT><Red>" with "sniperrifle" (customkill "headshot") (attacker_position "1 1 1") (victim_position "2 2 2")`, "Bot <Heavy>", "sniperrifle", "headshot", "BOT"},
+	}
+
+	for _, test := range validCases {
+		actual, ok := parseClientKilled(clientLog(t, test.message))
+		if !ok {
+			t.Errorf("Message %q should have successfully parsed.", test.message)
+			continue
+		}
+
+		if actual.victim.Username != test.victim || actual.victim.SteamID != test.victimID || actual.weapon != test.weapon || actual.customKill != test.custom {
+			t.Errorf("Unexpected kill %+v parsed from %q.", actual, test.message)
+		}
+	}
+
+	if _, ok := parseClientKilled(clientLog(t, `"Alpha<2><[U:1:1001]><Blue>" committed suicide with "world" (attacker_position "1 1 1")`)); ok {
+		t.Error("A suicide should NOT have successfully parsed as a kill.")
+	}
+}
+
+func Test_parsePointCaptured(t *testing.T) {
+	message := `Team "Red" triggered "pointcaptured" (cp "2") (cpname "#Gravelpit_cap_C") (numcappers "2") (player1 "Xray<3><[U:1:2001]><Red>") (position1 "1 2 3") (player2 "Yankee<4><[U:1:2002]><Red>") (position2 "4 5 6")`
+
+	actual, ok := parsePointCaptured(srcds.LogEntry{Message: message})
+	if !ok {
+		t.Fatalf("Message %q should have successfully parsed.", message)
+	}
+
+	if actual.affiliation != red || actual.cp != 2 || actual.cpName != "#Gravelpit_cap_C" || len(actual.cappers) != 2 || actual.cappers[1].SteamID != "U:1:2002" {
+		t.Errorf("Unexpected point capture %+v.", actual)
+	}
+
+	if _, ok := parsePointCaptured(srcds.LogEntry{Message: `Team "Red" triggered "captureblocked" (cp "2")`}); ok {
+		t.Error("A blocked capture should NOT have successfully parsed.")
+	}
+}
+
+func Test_parseRoundWin(t *testing.T) {
+	if actual, ok := parseRoundWin(srcds.LogEntry{Message: `World triggered "Round_Win" (winner "Blue")`}); !ok || actual != blue {
+		t.Errorf("Expected Blue to have won the round not %q.", actual)
+	}
+
+	if _, ok := parseRoundWin(srcds.LogEntry{Message: `World triggered "Round_Stalemate"`}); ok {
+		t.Error("A stalemate should NOT have successfully parsed as a round win.")
+	}
+}
+
+func Test_parseTeamScore(t *testing.T) {
+	validCases := []struct {
+		message  string
+		expected teamScore
+	}{
+		{`Team "Red" current score "2" with "6" players`, teamScore{affiliation: red, score: 2, playerCount: 6}},
+		{`Team "Blue" final score "5" with "9" players`, teamScore{affiliation: blue, final: true, score: 5, playerCount: 9}},
+	}
+
+	for _, test := range validCases {
+		if actual, ok := parseTeamScore(srcds.LogEntry{Message: test.message}); !ok || actual != test.expected {
+			t.Errorf("Expected team score %+v not %+v.", test.expected, actual)
+		}
+	}
+}
+
+func Test_parseTournament(t *testing.T) {
+	if !parseTournamentStarted(srcds.LogEntry{Message: "Tournament mode started"}) {
+		t.Error("Tournament mode starting should have successfully parsed.")
+	}
+
+	if actual, ok := parseTournamentTeamName(srcds.LogEntry{Message: "Blue Team: Laclede's LAN "}); !ok || actual.affiliation != blue || actual.teamName != "Laclede's LAN" {
+		t.Errorf("Unexpected tournament team name %+v.", actual)
+	}
+
+	validRestarts := []string{`rcon from "192.168.1.10:50123": command "mp_tournament_restart"`, `mp_tournament_restart`}
+	for _, line := range validRestarts {
+		if !parseTournamentRestart(line) {
+			t.Errorf("Line %q should have successfully parsed as a tournament restart.", line)
+		}
+	}
+
+	if parseTournamentRestart(`mp_tournament_restarts`) {
+		t.Error("An unknown command should NOT have successfully parsed as a tournament restart.")
+	}
+}
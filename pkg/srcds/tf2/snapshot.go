@@ -0,0 +1,126 @@
+package tf2
+
+import (
+	"time"
+)
+
+// MatchSnapshot is a point-in-time copy of a match's state
+type MatchSnapshot struct {
+	Number    int
+	MapName   string
+	Started   time.Time
+	Ended     time.Time
+	RedScore  int
+	BlueScore int
+	Rounds    []RoundSnapshot
+	Players   map[string]PlayerStats // keyed by SteamID; bots are keyed by "BOT:" and their username
+}
+
+// RoundSnapshot is a point-in-time copy of a completed round's state
+type RoundSnapshot struct {
+	Number   int
+	Started  time.Time
+	Ended    time.Time
+	Winner   string // Red or Blue; empty for a stalemate
+	Captures []Capture
+}
+
+// Capture is a control point (or payload checkpoint) captured during a round
+type Capture struct {
+	At      time.Time
+	Team    string
+	Point   int
+	Name    string
+	Cappers []string // SteamIDs; bots are "BOT:" and their username
+}
+
+// PlayerStats are a player's totals for a match
+type PlayerStats struct {
+	Username string
+	Team     string
+	Class    string // most recently played class
+	Kills    int
+	Deaths   int
+	Captures int
+}
+
+// CurrentMatch returns a snapshot of the current match; false if no match has been observed
+func (o *Observer) CurrentMatch() (MatchSnapshot, bool) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	if len(o.game.matches) == 0 {
+		return MatchSnapshot{}, false
+	}
+
+	i := len(o.game.matches) - 1
+	return o.game.matches[i].snapshot(i + 1), true
+}
+
+// Matches returns snapshots of every observed match
+func (o *Observer) Matches() []MatchSnapshot {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	r := make([]MatchSnapshot, 0, len(o.game.matches))
+	for i := range o.game.matches {
+		r = append(r, o.game.matches[i].snapshot(i+1))
+	}
+
+	return r
+}
+
+// TeamNames returns the tournament names of the red and blue teams
+func (o *Observer) TeamNames() (red, blue string) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	return o.game.redTeamName, o.game.blueTeamName
+}
+
+func (m *matchInfo) snapshot(number int) MatchSnapshot {
+	r := MatchSnapshot{
+		Number:    number,
+		MapName:   m.mapName,
+		Started:   m.started,
+		Ended:     m.ended,
+		RedScore:  m.scores[red],
+		BlueScore: m.scores[blue],
+		Rounds:    make([]RoundSnapshot, 0, len(m.rounds)),
+		Players:   make(map[string]PlayerStats, len(m.players)),
+	}
+
+	for i, round := range m.rounds {
+		s := RoundSnapshot{
+			Number:  i + 1,
+			Started: round.started,
+			Ended:   round.ended,
+			Winner:  string(round.winner),
+		}
+
+		for _, c := range round.captures {
+			s.Captures = append(s.Captures, Capture{
+				At:      c.at,
+				Team:    string(c.affiliation),
+				Point:   c.cp,
+				Name:    c.cpName,
+				Cappers: append([]string(nil), c.cappers...),
+			})
+		}
+
+		r.Rounds = append(r.Rounds, s)
+	}
+
+	for id, p := range m.players {
+		r.Players[id] = PlayerStats{
+			Username: p.username,
+			Team:     string(p.affiliation),
+			Class:    p.class,
+			Kills:    p.kills,
+			Deaths:   p.deaths,
+			Captures: p.captures,
+		}
+	}
+
+	return r
+}